@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// aggregate collects information over all certificates in a bulk run to report
+// issues that can only be found by comparing certificates with each other.
+type aggregate interface {
+	Add(r testResult)
+	Check() *errors.Errors
+}
+
+// newAggregates returns all aggregate checks performed on a bulk run
+func newAggregates() []aggregate {
+	return []aggregate{
+		newSKIMethods(),
+		newSerialCollisions(),
+		newSerialEntropy(),
+	}
+}
+
+// checkAggregates returns the findings of all aggregates, the severity
+// overrides and -min-severity apply to them as to certificate findings.
+func checkAggregates(aggregates []aggregate) *errors.Errors {
+	var summary = errors.New(nil)
+	for _, a := range aggregates {
+		summary.Append(a.Check())
+	}
+	return reportFindings(summary)
+}
+
+// minSerialSample is the number of serial numbers of an issuer needed before
+// the serial numbers are analyzed, a random bit is equal in all of them with a
+// probability of 2^-31.
+const minSerialSample = 32
+
+// maxSequentialGap is the largest difference between two serial numbers that
+// is considered sequential, the expected gap between 64 bit random serial
+// numbers is many orders of magnitude larger.
+var maxSequentialGap = big.NewInt(1 << 16)
+
+// skiMethods keeps track of the SubjectKeyId derivation methods per issuer
+type skiMethods struct {
+	issuers map[string]string
+	methods map[string]map[string]int
+}
+
+func newSKIMethods() *skiMethods {
+	return &skiMethods{
+		issuers: make(map[string]string),
+		methods: make(map[string]map[string]int),
+	}
+}
+
+// Add registers the SubjectKeyId method of this certificate for its issuer
+func (s *skiMethods) Add(r testResult) {
+	if r.Cert == nil || len(r.Cert.SubjectKeyId) == 0 {
+		return
+	}
+
+	key := string(r.Cert.RawIssuer)
+	if _, ok := s.methods[key]; !ok {
+		s.issuers[key] = r.Cert.Issuer.CommonName
+		s.methods[key] = make(map[string]int)
+	}
+	s.methods[key][skiMethod(r.Cert)]++
+}
+
+// Check reports all issuers that have used more than one SubjectKeyId method
+func (s *skiMethods) Check() *errors.Errors {
+	var e = errors.New(nil)
+	for key, methods := range s.methods {
+		if len(methods) < 2 {
+			continue
+		}
+
+		var list []string
+		for m, c := range methods {
+			list = append(list, fmt.Sprintf("%s (%d)", m, c))
+		}
+		sort.Strings(list)
+		e.Notice("Certificates issued by '%s' use different SubjectKeyId methods: %s", s.issuers[key], strings.Join(list, ", "))
+	}
+	return e
+}
+
+// serialCollisions keeps track of the serial numbers used per issuer
+type serialCollisions struct {
+	issuers  map[string]string
+	subjects map[string]map[[32]byte]string
+}
+
+func newSerialCollisions() *serialCollisions {
+	return &serialCollisions{
+		issuers:  make(map[string]string),
+		subjects: make(map[string]map[[32]byte]string),
+	}
+}
+
+// Add registers the issuer and serial number of this certificate
+func (s *serialCollisions) Add(r testResult) {
+	// A precertificate and the final certificate share the same serial number
+	if r.Cert == nil || r.Precert {
+		return
+	}
+
+	key := fmt.Sprintf("%x/%x", r.Cert.RawIssuer, r.Cert.SerialNumber)
+	if _, ok := s.subjects[key]; !ok {
+		s.issuers[key] = r.Cert.Issuer.CommonName
+		s.subjects[key] = make(map[[32]byte]string)
+	}
+
+	// The same certificate can be included multiple times
+	s.subjects[key][sha256.Sum256(r.Cert.Raw)] = r.Cert.Subject.CommonName
+}
+
+// Check reports all serial numbers that have been used more than once by the
+// same issuer.
+func (s *serialCollisions) Check() *errors.Errors {
+	var e = errors.New(nil)
+	for key, subjects := range s.subjects {
+		if len(subjects) < 2 {
+			continue
+		}
+
+		var list []string
+		for _, cn := range subjects {
+			list = append(list, fmt.Sprintf("'%s'", cn))
+		}
+		sort.Strings(list)
+		serial := key[strings.Index(key, "/")+1:]
+		e.Crit("Certificates issued by '%s' share serial number %s: %s", s.issuers[key], serial, strings.Join(list, ", "))
+	}
+	return e
+}
+
+// serialEntropy keeps track of the end-entity serial numbers per issuer to
+// estimate whether they contain at least 64 bits of CSPRNG output, BR §7.1
+type serialEntropy struct {
+	issuers map[string]string
+	serials map[string]map[string]*big.Int
+}
+
+func newSerialEntropy() *serialEntropy {
+	return &serialEntropy{
+		issuers: make(map[string]string),
+		serials: make(map[string]map[string]*big.Int),
+	}
+}
+
+// Add registers the serial number of this end-entity certificate
+func (s *serialEntropy) Add(r testResult) {
+	if r.Cert == nil || r.Cert.IsCA || r.Cert.SerialNumber.Sign() <= 0 {
+		return
+	}
+
+	key := string(r.Cert.RawIssuer)
+	if _, ok := s.serials[key]; !ok {
+		s.issuers[key] = r.Cert.Issuer.CommonName
+		s.serials[key] = make(map[string]*big.Int)
+	}
+
+	// A precertificate and the final certificate share the same serial number
+	s.serials[key][r.Cert.SerialNumber.String()] = r.Cert.SerialNumber
+}
+
+// Check reports the issuers of which the serial numbers are sequential or
+// vary in fewer than 64 bits.
+func (s *serialEntropy) Check() *errors.Errors {
+	var e = errors.New(nil)
+	for key, serials := range s.serials {
+		if len(serials) < minSerialSample {
+			continue
+		}
+
+		var list []*big.Int
+		for _, n := range serials {
+			list = append(list, n)
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Cmp(list[j]) < 0 })
+
+		var sequential int
+		gap := new(big.Int)
+		for i := 1; i < len(list); i++ {
+			if gap.Sub(list[i], list[i-1]).Cmp(maxSequentialGap) <= 0 {
+				sequential++
+			}
+		}
+		if sequential*2 >= len(list)-1 {
+			e.Err("Certificates issued by '%s' use sequential serial numbers, %d of %d follow closely on the previous serial number", s.issuers[key], sequential, len(list))
+		}
+
+		if bits := varyingBits(list); bits < 64 {
+			e.Err("Certificates issued by '%s' have serial numbers in which only %d bits vary, expected at least 64 bits of CSPRNG output", s.issuers[key], bits)
+		}
+	}
+	return e
+}
+
+// varyingBits returns the number of bit positions that are not equal in all
+// serial numbers, bits fixed by the CA such as a prefix or a cleared sign bit
+// do not contribute to the entropy.
+func varyingBits(serials []*big.Int) int {
+	var max int
+	for _, n := range serials {
+		if n.BitLen() > max {
+			max = n.BitLen()
+		}
+	}
+
+	var bits int
+	for i := 0; i < max; i++ {
+		for _, n := range serials[1:] {
+			if n.Bit(i) != serials[0].Bit(i) {
+				bits++
+				break
+			}
+		}
+	}
+	return bits
+}
+
+// skiMethod determines how the SubjectKeyId has been derived from the public
+// key, using the methods described in RFC 5280 section 4.2.1.2 and RFC 7093.
+func skiMethod(c *x509.Certificate) string {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(c.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return "unknown"
+	}
+
+	ski := c.SubjectKeyId
+	key := spki.PublicKey.Bytes
+
+	s1 := sha1.Sum(key)
+	if bytes.Equal(ski, s1[:]) {
+		return "SHA-1"
+	}
+	if len(ski) == 8 && ski[0]&0xf0 == 0x40 && ski[0]&0x0f == s1[12]&0x0f && bytes.Equal(ski[1:], s1[13:]) {
+		return "SHA-1 (60 bits)"
+	}
+
+	s256 := sha256.Sum256(key)
+	if len(ski) <= len(s256) && bytes.Equal(ski, s256[:len(ski)]) {
+		return "SHA-256"
+	}
+	s384 := sha512.Sum384(key)
+	if len(ski) <= len(s384) && bytes.Equal(ski, s384[:len(ski)]) {
+		return "SHA-384"
+	}
+	s512 := sha512.Sum512(key)
+	if len(ski) <= len(s512) && bytes.Equal(ski, s512[:len(ski)]) {
+		return "SHA-512"
+	}
+
+	return fmt.Sprintf("unknown (%d bytes)", len(ski))
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+var testIssuerKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+// newTestResult returns a result with a certificate issued by the same test
+// issuer, the given function can modify the template before signing.
+func newTestResult(t *testing.T, serial int64, f func(*x509.Certificate, []byte)) testResult {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := &x509.Certificate{Subject: pkix.Name{CommonName: "Test Issuer"}}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "www.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	if f != nil {
+		f(tmpl, elliptic.Marshal(k.Curve, k.X, k.Y))
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, issuer, &k.PublicKey, testIssuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testResult{Cert: c, Der: der}
+}
+
+func TestSKIMethods(t *testing.T) {
+	sha1SKI := func(c *x509.Certificate, key []byte) {
+		s := sha1.Sum(key)
+		c.SubjectKeyId = s[:]
+	}
+	sha256SKI := func(c *x509.Certificate, key []byte) {
+		s := sha256.Sum256(key)
+		c.SubjectKeyId = s[:20]
+	}
+
+	a := newSKIMethods()
+	a.Add(newTestResult(t, 1, sha1SKI))
+	a.Add(newTestResult(t, 2, sha1SKI))
+	if e := a.Check(); len(e.List()) != 0 {
+		t.Errorf("Unexpected findings for consistent SubjectKeyId methods: %v", e.List())
+	}
+
+	r := newTestResult(t, 3, sha256SKI)
+	if m := skiMethod(r.Cert); m != "SHA-256" {
+		t.Errorf("Unexpected SubjectKeyId method got '%s', want 'SHA-256'", m)
+	}
+	a.Add(r)
+	if e := a.Check(); len(e.List()) != 1 {
+		t.Errorf("Expected one finding for mixed SubjectKeyId methods, got %d", len(e.List()))
+	}
+}
+
+func TestSerialCollisions(t *testing.T) {
+	a := newSerialCollisions()
+
+	r := newTestResult(t, 42, nil)
+	a.Add(r)
+	a.Add(r)
+	a.Add(newTestResult(t, 43, nil))
+	if e := a.Check(); len(e.List()) != 0 {
+		t.Errorf("Unexpected findings for unique serial numbers: %v", e.List())
+	}
+
+	a.Add(newTestResult(t, 42, nil))
+	e := a.Check()
+	if len(e.List()) != 1 {
+		t.Fatalf("Expected one finding for a serial number collision, got %d", len(e.List()))
+	}
+	if e.Priority() != errors.Critical {
+		t.Errorf("Unexpected priority got %s, want %s", e.Priority(), errors.Critical)
+	}
+}
+
+func TestSerialEntropy(t *testing.T) {
+	a := newSerialEntropy()
+	for i := 0; i < minSerialSample; i++ {
+		serial := make([]byte, 16)
+		rand.Read(serial)
+		r := newTestResult(t, 1, nil)
+		r.Cert.SerialNumber = new(big.Int).SetBytes(serial)
+		a.Add(r)
+	}
+	if e := a.Check(); len(e.List()) != 0 {
+		t.Errorf("Unexpected findings for random serial numbers: %v", e.List())
+	}
+
+	// A 32 bit prefix with a sequential counter
+	s := newSerialEntropy()
+	for i := 0; i < minSerialSample; i++ {
+		r := newTestResult(t, 0x1234567800000000+int64(i), nil)
+		s.Add(r)
+		s.Add(r)
+	}
+	e := s.Check()
+	if len(e.List()) != 2 {
+		t.Errorf("Expected findings for sequential serial numbers and low entropy, got %v", e.List())
+	}
+}
@@ -14,6 +14,7 @@ type Linter struct {
 // encoding of the input der.
 func (l *Linter) CheckStruct(der []byte) *errors.Errors {
 	l.walk(der)
+	l.checkCertificate(der)
 	if l.e.IsError() {
 		return &l.e
 	}
@@ -32,7 +33,7 @@ func (l *Linter) walk(der []byte) {
 		if err != nil {
 			// Errors should be included in the report, but allow format checking when
 			// data has been decoded.
-			l.e.Err(err.Error())
+			l.e.Err("Failed to decode ASN.1 structure: %s", err)
 			if len(d.Bytes) == 0 {
 				return
 			}
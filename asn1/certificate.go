@@ -0,0 +1,165 @@
+package asn1
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"net"
+	"strings"
+)
+
+var (
+	oidBasicConstraints = asn1.ObjectIdentifier{2, 5, 29, 19}
+	oidSubjectAltName   = asn1.ObjectIdentifier{2, 5, 29, 17}
+	oidRSAEncryption    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+)
+
+// maxPathLen is the highest pathLenConstraint we consider to be realistic
+var maxPathLen = big.NewInt(100)
+
+// maxExponent is the largest RSA public exponent allowed by BR §6.1.6, 2^64-1
+var maxExponent = new(big.Int).SetUint64(1<<64 - 1)
+
+// Certificate only decodes the fields of a certificate we need to inspect before
+// the x509 parser rejects, clamps or otherwise alters the values. Marshaling it
+// again results in a strict DER encoding of the certificate.
+type Certificate struct {
+	TBSCertificate     TBSCertificate
+	SignatureAlgorithm asn1.RawValue
+	SignatureValue     asn1.BitString
+}
+
+// TBSCertificate is the to be signed part of a certificate, RFC 5280 section
+// 4.1
+type TBSCertificate struct {
+	Version            int `asn1:"optional,explicit,default:0,tag:0"`
+	SerialNumber       asn1.RawValue
+	SignatureAlgorithm asn1.RawValue
+	Issuer             asn1.RawValue
+	Validity           asn1.RawValue
+	Subject            asn1.RawValue
+	PublicKey          asn1.RawValue
+	UniqueID           asn1.BitString   `asn1:"optional,tag:1"`
+	SubjectUniqueID    asn1.BitString   `asn1:"optional,tag:2"`
+	Extensions         []pkix.Extension `asn1:"optional,explicit,tag:3"`
+}
+
+// checkCertificate performs checks on the raw certificate structure, errors in
+// the encoding itself are already reported while walking the structure.
+func (l *Linter) checkCertificate(der []byte) {
+	var c Certificate
+	if _, err := asn1.Unmarshal(der, &c); err != nil {
+		return
+	}
+
+	// Extensions are only allowed in version 3 certificates, the encoded version
+	// is one less than the actual version
+	if c.TBSCertificate.Version < 2 && len(c.TBSCertificate.Extensions) > 0 {
+		l.e.Err("Certificate is version %d but contains extensions, which require version 3", c.TBSCertificate.Version+1)
+	}
+
+	// A certificate must not include more than one instance of an extension,
+	// duplicate subjectAltName extensions could result in a different name
+	// being validated by different clients.
+	seen := make(map[string]int)
+	for _, ext := range c.TBSCertificate.Extensions {
+		seen[ext.Id.String()]++
+		if seen[ext.Id.String()] != 2 {
+			continue
+		}
+		if ext.Id.Equal(oidSubjectAltName) {
+			l.e.Err("Certificate contains more than one SubjectAltName extension")
+		} else {
+			l.e.Err("Certificate contains duplicate extension (%s)", ext.Id.String())
+		}
+	}
+
+	l.checkName("Issuer", c.TBSCertificate.Issuer)
+	l.checkName("Subject", c.TBSCertificate.Subject)
+	l.checkPublicKey(c.TBSCertificate.PublicKey)
+
+	for _, ext := range c.TBSCertificate.Extensions {
+		switch {
+		case ext.Id.Equal(oidBasicConstraints):
+			l.checkBasicConstraints(ext.Value)
+		case ext.Id.Equal(oidSubjectAltName):
+			l.checkSubjectAltName(ext.Value)
+		}
+	}
+}
+
+// checkPublicKey verifies the raw RSA public exponent, the x509 parser rejects
+// exponents that do not fit an int before the public key check can run.
+func (l *Linter) checkPublicKey(raw asn1.RawValue) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(raw.FullBytes, &spki); err != nil || !spki.Algorithm.Algorithm.Equal(oidRSAEncryption) {
+		return
+	}
+
+	var key struct {
+		N *big.Int
+		E *big.Int
+	}
+	if _, err := asn1.Unmarshal(spki.PublicKey.Bytes, &key); err != nil {
+		l.e.Err("Failed to parse RSA public key: %s", err.Error())
+		return
+	}
+	if key.E.Cmp(maxExponent) > 0 {
+		l.e.Err("Certificate RSA public exponent %s exceeds 2^64-1", key.E.String())
+	}
+}
+
+// checkBasicConstraints verifies the raw pathLenConstraint, this value is
+// defined as INTEGER (0..MAX) in RFC 5280 section 4.2.1.9.
+func (l *Linter) checkBasicConstraints(value []byte) {
+	var bc struct {
+		IsCA       bool     `asn1:"optional"`
+		MaxPathLen *big.Int `asn1:"optional"`
+	}
+	if _, err := asn1.Unmarshal(value, &bc); err != nil {
+		l.e.Err("Failed to parse BasicConstraints extension: %s", err.Error())
+		return
+	}
+	if bc.MaxPathLen == nil {
+		return
+	}
+
+	if bc.MaxPathLen.Sign() < 0 {
+		l.e.Err("BasicConstraints pathLenConstraint must not be negative (%s)", bc.MaxPathLen.String())
+	} else if bc.MaxPathLen.Cmp(maxPathLen) > 0 {
+		l.e.Err("BasicConstraints pathLenConstraint is unreasonably large (%s)", bc.MaxPathLen.String())
+	}
+}
+
+// checkSubjectAltName verifies the raw iPAddress entries, which contain 4
+// octets for IPv4 or 16 octets for IPv6, and the dNSName entries, which are
+// IA5Strings with internationalized labels encoded as A-labels, RFC 5280
+// section 4.2.1.6. The x509 parser rejects the certificate otherwise.
+func (l *Linter) checkSubjectAltName(value []byte) {
+	var names []asn1.RawValue
+	if _, err := asn1.Unmarshal(value, &names); err != nil {
+		// Reported by the SubjectAltName Extension Check
+		return
+	}
+	for _, n := range names {
+		if n.Class != asn1.ClassContextSpecific {
+			continue
+		}
+		switch n.Tag {
+		case 2:
+			for _, b := range n.Bytes {
+				if b >= 0x80 {
+					l.e.Err("SubjectAltName dNSName '%s' contains non-ASCII characters", strings.ToValidUTF8(string(n.Bytes), "?"))
+					break
+				}
+			}
+		case 7:
+			if len(n.Bytes) != net.IPv4len && len(n.Bytes) != net.IPv6len {
+				l.e.Err("SubjectAltName iPAddress has an invalid length of %d octets", len(n.Bytes))
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package asn1
+
+import "github.com/weyhmueller/certlint/errors"
+
+// init registers the stable codes of the ASN.1 findings, see
+// errors.RegisterCodes
+func init() {
+	errors.RegisterCodes(map[string]string{
+		// asn1.go
+		"Failed to decode ASN.1 structure: %s": "CERTLINT_ASN1_001",
+		// certificate.go
+		"Certificate is version %d but contains extensions, which require version 3": "CERTLINT_ASN1_002",
+		"Certificate contains more than one SubjectAltName extension":                "CERTLINT_ASN1_003",
+		"Certificate contains duplicate extension (%s)":                              "CERTLINT_ASN1_004",
+		"Failed to parse BasicConstraints extension: %s":                             "CERTLINT_ASN1_005",
+		"BasicConstraints pathLenConstraint must not be negative (%s)":               "CERTLINT_ASN1_006",
+		"BasicConstraints pathLenConstraint is unreasonably large (%s)":              "CERTLINT_ASN1_007",
+		"SubjectAltName iPAddress has an invalid length of %d octets":                "CERTLINT_ASN1_040",
+		"SubjectAltName dNSName '%s' contains non-ASCII characters":                  "CERTLINT_ASN1_041",
+		"Failed to parse RSA public key: %s":                                         "CERTLINT_ASN1_047",
+		"Certificate RSA public exponent %s exceeds 2^64-1":                          "CERTLINT_ASN1_048",
+		// name.go
+		"Failed to parse %s name: %s":                            "CERTLINT_ASN1_042",
+		"%s %s is encoded as %s, but must be a %s":               "CERTLINT_ASN1_043",
+		"%s %s is encoded as %s, which is not a DirectoryString": "CERTLINT_ASN1_044",
+		// format.go
+		"Invalid UTF8 encoding in UTF8String":       "CERTLINT_ASN1_008",
+		"Forbidden value in UTF8String '%s'":        "CERTLINT_ASN1_009",
+		"Control character in UTF8String '%s'":      "CERTLINT_ASN1_010",
+		"Invalid character in NumericString '%s'":   "CERTLINT_ASN1_011",
+		"Invalid character in PrintableString '%s'": "CERTLINT_ASN1_012",
+		"Forbidden value in PrintableString '%s'":   "CERTLINT_ASN1_013",
+		"Using deprecated TeletexString for '%s'":   "CERTLINT_ASN1_014",
+		"Forbidden value in TeletexString '%s'":     "CERTLINT_ASN1_015",
+		"Control character in TeletexString '%s'":   "CERTLINT_ASN1_016",
+		"Using deprecated VideotexString for '%s'":  "CERTLINT_ASN1_017",
+		"Forbidden value in VideotexString '%s'":    "CERTLINT_ASN1_018",
+		"Control character in VideotexString '%s'":  "CERTLINT_ASN1_019",
+		"Invalid character in IA5String '%s'":       "CERTLINT_ASN1_020",
+		"Forbidden value in IA5String '%s'":         "CERTLINT_ASN1_021",
+		"UTCTime not in Zulu/GMT":                   "CERTLINT_ASN1_022",
+		"Invalid UTCTime":                           "CERTLINT_ASN1_023",
+		"Failed to parse Generalized Time: %s":      "CERTLINT_ASN1_024",
+		"Generalized Time not in Zulu/GMT":          "CERTLINT_ASN1_025",
+		"Generalized Time before 2050":              "CERTLINT_ASN1_026",
+		"Invalid Generalized Time":                  "CERTLINT_ASN1_027",
+		"Using deprecated GraphicString for '%s'":   "CERTLINT_ASN1_028",
+		"Forbidden value in GraphicString '%s'":     "CERTLINT_ASN1_029",
+		"Control character in GraphicString '%s'":   "CERTLINT_ASN1_030",
+		"Using deprecated GeneralString for '%s'":   "CERTLINT_ASN1_031",
+		"Forbidden value in GeneralString '%s'":     "CERTLINT_ASN1_032",
+		"Control character in GeneralString '%s'":   "CERTLINT_ASN1_033",
+		"Using deprecated UniversalString for '%s'": "CERTLINT_ASN1_034",
+		"Forbidden value in UniversalString '%s'":   "CERTLINT_ASN1_035",
+		"Control character in UniversalString '%s'": "CERTLINT_ASN1_036",
+		"Using deprecated BMPString for '%s'":       "CERTLINT_ASN1_037",
+		"Forbidden value in BMPString '%s'":         "CERTLINT_ASN1_038",
+		"Control character in BMPString '%s'":       "CERTLINT_ASN1_039",
+		"Invalid UCS-4 encoding in UniversalString": "CERTLINT_ASN1_045",
+		"Invalid UCS-2 encoding in BMPString":       "CERTLINT_ASN1_046",
+	})
+}
@@ -128,26 +128,54 @@ func (l *Linter) CheckFormat(d asn1.RawValue) {
 				l.e.Err("Control character in GeneralString '%s'", string(d.Bytes))
 			}
 		case 28: // "UniversalString"
-			l.e.Warning("Using deprecated UniversalString for '%s'", string(d.Bytes))
-			if isForbiddenString(d.Bytes) {
-				l.e.Err("Forbidden value in UniversalString '%s'", string(d.Bytes))
+			b, ok := decodeUCS(d.Bytes, 4)
+			if !ok {
+				l.e.Err("Invalid UCS-4 encoding in UniversalString")
 			}
-			if isControlCharacter(d.Bytes) {
-				l.e.Err("Control character in UniversalString '%s'", string(d.Bytes))
+			l.e.Warning("Using deprecated UniversalString for '%s'", string(b))
+			if isForbiddenString(b) {
+				l.e.Err("Forbidden value in UniversalString '%s'", string(b))
+			}
+			if isControlCharacter(b) {
+				l.e.Err("Control character in UniversalString '%s'", string(b))
 			}
 		case 29: // "CHARACTER STRING"
 		case 30: // "BMPString"
-			l.e.Warning("Using deprecated BMPString for '%s'", string(d.Bytes))
-			if isForbiddenString(d.Bytes) {
-				l.e.Err("Forbidden value in BMPString '%s'", string(d.Bytes))
+			b, ok := decodeUCS(d.Bytes, 2)
+			if !ok {
+				l.e.Err("Invalid UCS-2 encoding in BMPString")
 			}
-			if isControlCharacter(d.Bytes) {
-				l.e.Err("Control character in BMPString '%s'", string(d.Bytes))
+			l.e.Warning("Using deprecated BMPString for '%s'", string(b))
+			if isForbiddenString(b) {
+				l.e.Err("Forbidden value in BMPString '%s'", string(b))
+			}
+			if isControlCharacter(b) {
+				l.e.Err("Control character in BMPString '%s'", string(b))
 			}
 		}
 	}
 }
 
+// decodeUCS converts a big endian UCS-2 (BMPString) or UCS-4 (UniversalString)
+// value of size octets per character to UTF-8, surrogates and values outside
+// of the Unicode range are invalid characters.
+func decodeUCS(b []byte, size int) ([]byte, bool) {
+	var out []byte
+	ok := len(b)%size == 0
+	for ; len(b) >= size; b = b[size:] {
+		var r rune
+		for _, c := range b[:size] {
+			r = r<<8 | rune(c)
+		}
+		if !utf8.ValidRune(r) {
+			ok = false
+			r = utf8.RuneError
+		}
+		out = utf8.AppendRune(out, r)
+	}
+	return out, ok
+}
+
 // Version of isPrintable without allowing a *
 // Source: https://golang.org/src/encoding/asn1/asn1.go
 func isPrintable(b byte) bool {
@@ -174,7 +202,7 @@ func isIA5String(b []byte) bool {
 	return true
 }
 
-// 	1, 2, 3, 4, 5, 6, 7, 8, 9, 0, and SPACE
+// 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, and SPACE
 func isNumericString(b []byte) bool {
 	for len(b) > 0 {
 		r, size := utf8.DecodeRune(b)
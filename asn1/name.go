@@ -0,0 +1,105 @@
+package asn1
+
+import (
+	"encoding/asn1"
+	"strconv"
+)
+
+// attributeTypeAndValue is a raw attribute of a distinguished name, the value
+// is kept raw to inspect the string type used to encode it.
+type attributeTypeAndValue struct {
+	Type  asn1.ObjectIdentifier
+	Value asn1.RawValue
+}
+
+// stringTypes are the names of the ASN.1 string tags
+var stringTypes = map[int]string{
+	12: "UTF8String",
+	18: "NumericString",
+	19: "PrintableString",
+	20: "TeletexString",
+	22: "IA5String",
+	26: "VisibleString",
+	28: "UniversalString",
+	30: "BMPString",
+}
+
+// fixedAttributes are the attributes that must be encoded with a specific
+// string type, RFC 5280 appendix A.1 and EV Guidelines §9.2.4
+var fixedAttributes = map[string]struct {
+	name string
+	tag  int
+}{
+	"2.5.4.5":                    {"serialNumber", 19},
+	"2.5.4.6":                    {"countryName", 19},
+	"2.5.4.46":                   {"dnQualifier", 19},
+	"1.3.6.1.4.1.311.60.2.1.3":   {"jurisdictionCountryName", 19},
+	"1.2.840.113549.1.9.1":       {"emailAddress", 22},
+	"0.9.2342.19200300.100.1.25": {"domainComponent", 22},
+}
+
+// directoryStringAttributes are the attributes with a DirectoryString value,
+// which is a TeletexString, PrintableString, UniversalString, UTF8String or
+// BMPString, RFC 5280 section 4.1.2.4
+var directoryStringAttributes = map[string]string{
+	"2.5.4.3":                  "commonName",
+	"2.5.4.4":                  "surname",
+	"2.5.4.7":                  "localityName",
+	"2.5.4.8":                  "stateOrProvinceName",
+	"2.5.4.9":                  "streetAddress",
+	"2.5.4.10":                 "organizationName",
+	"2.5.4.11":                 "organizationalUnitName",
+	"2.5.4.12":                 "title",
+	"2.5.4.15":                 "businessCategory",
+	"2.5.4.17":                 "postalCode",
+	"2.5.4.42":                 "givenName",
+	"2.5.4.43":                 "initials",
+	"2.5.4.44":                 "generationQualifier",
+	"2.5.4.65":                 "pseudonym",
+	"2.5.4.97":                 "organizationIdentifier",
+	"1.3.6.1.4.1.311.60.2.1.1": "jurisdictionLocalityName",
+	"1.3.6.1.4.1.311.60.2.1.2": "jurisdictionStateOrProvinceName",
+}
+
+// checkName verifies the string types of the attribute values in the issuer
+// or subject name, the content of the strings is verified while walking the
+// structure.
+func (l *Linter) checkName(field string, name asn1.RawValue) {
+	var rdns []asn1.RawValue
+	if _, err := asn1.Unmarshal(name.FullBytes, &rdns); err != nil {
+		l.e.Err("Failed to parse %s name: %s", field, err.Error())
+		return
+	}
+
+	for _, rdn := range rdns {
+		var atvs []attributeTypeAndValue
+		if _, err := asn1.UnmarshalWithParams(rdn.FullBytes, &atvs, "set"); err != nil {
+			l.e.Err("Failed to parse %s name: %s", field, err.Error())
+			return
+		}
+		for _, atv := range atvs {
+			if atv.Value.Class != asn1.ClassUniversal || atv.Value.IsCompound {
+				continue
+			}
+			oid := atv.Type.String()
+			if f, ok := fixedAttributes[oid]; ok && atv.Value.Tag != f.tag {
+				l.e.Err("%s %s is encoded as %s, but must be a %s", field, f.name, stringType(atv.Value.Tag), stringTypes[f.tag])
+				continue
+			}
+			if a, ok := directoryStringAttributes[oid]; ok {
+				switch atv.Value.Tag {
+				case 12, 19, 20, 28, 30:
+				default:
+					l.e.Err("%s %s is encoded as %s, which is not a DirectoryString", field, a, stringType(atv.Value.Tag))
+				}
+			}
+		}
+	}
+}
+
+func stringType(tag int) string {
+	if s, ok := stringTypes[tag]; ok {
+		return s
+	}
+	return "tag " + strconv.Itoa(tag)
+}
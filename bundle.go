@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"crypto/x509"
+	"os"
+)
+
+// certBundle contains the CA certificates found in a bulk file, used to verify
+// that all certificates chain to a root within the same file.
+type certBundle struct {
+	roots         *x509.CertPool
+	intermediates *x509.CertPool
+	certs         []*x509.Certificate
+}
+
+// selfContained is set when certificates should only be verified against the
+// CA certificates in the bulk file itself
+var selfContained *certBundle
+
+// chainBundle contains the certificates of a chain file, used to find the
+// issuers instead of fetching them from the AIA issuers URLs
+var chainBundle *certBundle
+
+// loadBundle reads all CA certificates from a bulk file, self-signed
+// certificates are used as roots.
+func loadBundle(file string) (*certBundle, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	b := &certBundle{
+		roots:         x509.NewCertPool(),
+		intermediates: x509.NewCertPool(),
+	}
+
+	err = scanCertificates(f, func(der, _ []byte, _ error) {
+		if der == nil {
+			return
+		}
+		c, err := x509.ParseCertificate(der)
+		if err != nil || !c.IsCA {
+			return
+		}
+
+		if bytes.Equal(c.RawSubject, c.RawIssuer) && c.CheckSignatureFrom(c) == nil {
+			b.roots.AddCert(c)
+		} else {
+			b.intermediates.AddCert(c)
+		}
+	})
+
+	return b, err
+}
+
+// newChainBundle returns a bundle of all certificates in a chain file, the
+// chains are verified against the system roots or the roots of -roots.
+func newChainBundle(ders [][]byte) *certBundle {
+	b := &certBundle{
+		roots:         customRoots,
+		intermediates: x509.NewCertPool(),
+	}
+	for _, der := range ders {
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			continue
+		}
+		b.intermediates.AddCert(c)
+		b.certs = append(b.certs, c)
+	}
+	return b
+}
+
+// issuer returns the certificate in the bundle that signed c
+func (b *certBundle) issuer(c *x509.Certificate) *x509.Certificate {
+	for _, ic := range b.certs {
+		if bytes.Equal(ic.RawSubject, c.RawIssuer) && c.CheckSignatureFrom(ic) == nil {
+			return ic
+		}
+	}
+	return nil
+}
+
+// verify builds the chains of the certificate using only the certificates in
+// the bundle, validity is checked at the time the certificate was issued.
+func (b *certBundle) verify(c *x509.Certificate) ([][]*x509.Certificate, error) {
+	return c.Verify(x509.VerifyOptions{
+		Roots:         b.roots,
+		Intermediates: b.intermediates,
+		CurrentTime:   c.NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSelfContained(t *testing.T) {
+	tests := map[string]bool{
+		"./testdata/bundle/complete.pem":   true,
+		"./testdata/bundle/incomplete.pem": false,
+	}
+
+	for file, complete := range tests {
+		b, err := loadBundle(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		selfContained = b
+
+		rest, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var block *pem.Block
+		for {
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+
+			result := do(nil, block.Bytes, nil, true, true)
+			var found bool
+			for _, e := range result.Errors.List() {
+				if strings.HasPrefix(e.Error(), "Certificate does not chain to a root in the bulk file") {
+					found = true
+				}
+			}
+			if found == complete {
+				t.Errorf("Unexpected chain result for %s in %s, chains: %t", result.Cert.Subject.CommonName, file, !found)
+			}
+		}
+	}
+	selfContained = nil
+}
+
+func TestChainBundle(t *testing.T) {
+	f, err := os.Open("./testdata/bundle/complete.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var ders [][]byte
+	scanCertificates(f, func(der, _ []byte, _ error) {
+		ders = append(ders, der)
+	})
+
+	chainBundle = newChainBundle(ders)
+	defer func() { chainBundle = nil }()
+
+	issuers := make(map[string]string)
+	for _, der := range ders {
+		result := do(nil, der, nil, true, true)
+		for _, e := range result.Errors.List() {
+			if strings.HasPrefix(e.Error(), "Failed to download issuer certificate") {
+				t.Errorf("Unexpected issuer download for %s: %s", result.Cert.Subject.CommonName, e.Error())
+			}
+		}
+		if result.Issuer != nil {
+			issuers[result.Cert.Subject.CommonName] = result.Issuer.Subject.CommonName
+		}
+	}
+
+	if issuers["bundle.example.com"] != "Certlint Test Bundle CA" {
+		t.Errorf("Expected issuer Certlint Test Bundle CA from the chain file, got '%s'", issuers["bundle.example.com"])
+	}
+	if issuers["Certlint Test Bundle CA"] != "Certlint Test Root CA" {
+		t.Errorf("Expected issuer Certlint Test Root CA from the chain file, got '%s'", issuers["Certlint Test Bundle CA"])
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// canonical returns a sorted list of all findings in a stable format that can
+// be used to compare results, e.g. against golden files. Each line contains
+// the code of the finding, the severity and the message, separated by tabs.
+func canonical(r testResult) []string {
+	var lines []string
+	for _, e := range r.Errors.List() {
+		code := e.Code()
+		if len(code) == 0 {
+			code = "-"
+		}
+		lines = append(lines, fmt.Sprintf("%s\t%s\t%s", code, strings.ToUpper(e.Priority().String()), e.Error()))
+	}
+	sort.Strings(lines)
+	return lines
+}
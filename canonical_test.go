@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/checks/certificate/psd2"
+	"github.com/weyhmueller/certlint/checks/certificate/smime"
+)
+
+var updateGolden = flag.Bool("update", false, "Update the golden files in testdata/golden")
+
+func TestCanonicalGolden(t *testing.T) {
+	defer func() { offline = false }()
+
+	files, err := filepath.Glob("./testdata/golden/*.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("No golden files found")
+	}
+
+	for _, golden := range files {
+		// The precertificate comparison has its own golden file
+		if filepath.Base(golden) == filepath.Base(precertGolden) {
+			continue
+		}
+		// The golden file <name>.<profile>.golden is checked using the profile
+		name := strings.TrimSuffix(filepath.Base(golden), ".golden")
+		var profile string
+		if i := strings.Index(name, "."); i >= 0 {
+			name, profile = name[:i], name[i+1:]
+		}
+		der := getCertificate("./testdata/" + name + ".pem")
+		if len(der) == 0 {
+			t.Errorf("Missing certificate for golden file %s", golden)
+			continue
+		}
+
+		// The issuer is read from the sidecar <name>.issuer.pem, without it the
+		// chain of the certificate is not fetched
+		issuer := "./testdata/golden/" + name + ".issuer.pem"
+		if _, err := os.Stat(issuer); err != nil {
+			issuer = ""
+		}
+		offline = len(issuer) == 0
+
+		reset := useProfile(t, profile)
+		result := do(nil, der, &issuer, true, true)
+		reset()
+		got := strings.Join(canonical(result), "\n") + "\n"
+
+		if *updateGolden {
+			if err := ioutil.WriteFile(golden, []byte(got), 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+
+		want, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != string(want) {
+			t.Errorf("Unexpected findings for %s\ngot:\n%s\nwant:\n%s", name, got, want)
+		}
+	}
+}
+
+// useProfile selects the checks, types and severities of the lint profile as
+// the profile option does, the returned function restores the defaults.
+func useProfile(t *testing.T, name string) func() {
+	if len(name) == 0 {
+		return func() {}
+	}
+
+	p, err := getProfile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checks.Select(nil, p.Exclude); err != nil {
+		t.Fatal(err)
+	}
+	severityOverrides = p.Overrides
+	onlyTypes = p.Types
+	psd2.Required = p.RequirePSD2
+	smime.Required = p.RequireSMIME
+
+	return func() {
+		checks.Select(nil, nil)
+		severityOverrides = nil
+		onlyTypes = nil
+		psd2.Required = false
+		smime.Required = false
+	}
+}
@@ -2,15 +2,24 @@ package certdata
 
 import (
 	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
 	"fmt"
 )
 
+// PoisonOID is the critical extension that makes a certificate a CT
+// precertificate, RFC 6962 §3.1
+var PoisonOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 3}
+
 // Data holds the certificate and relevant information
 // Type can be DV, OV, EV, PS, CS, EVCS, TS, OCSP, CA
+// Precert is set for CT precertificates, the Type is that of the final
+// certificate
 type Data struct {
-	Cert   *x509.Certificate
-	Issuer *x509.Certificate
-	Type   string
+	Cert    *x509.Certificate
+	Issuer  *x509.Certificate
+	Type    string
+	Precert bool
 }
 
 // Load raw certificate bytes into a Data struct
@@ -23,6 +32,8 @@ func Load(der []byte) (*Data, error) {
 		return nil, err
 	}
 
+	d.setPrecert()
+
 	if err = d.setCertificateType(); err != nil {
 		fmt.Println(err)
 	}
@@ -30,6 +41,54 @@ func Load(der []byte) (*Data, error) {
 	return d, nil
 }
 
+// setPrecert marks the certificate as a precertificate if it contains the
+// poison extension. The poison is removed from the unhandled critical
+// extensions, so the chain of a precertificate can be verified.
+func (d *Data) setPrecert() {
+	for _, ext := range d.Cert.Extensions {
+		if ext.Id.Equal(PoisonOID) {
+			d.Precert = true
+		}
+	}
+	if !d.Precert {
+		return
+	}
+
+	var unhandled []asn1.ObjectIdentifier
+	for _, oid := range d.Cert.UnhandledCriticalExtensions {
+		if !oid.Equal(PoisonOID) {
+			unhandled = append(unhandled, oid)
+		}
+	}
+	d.Cert.UnhandledCriticalExtensions = unhandled
+}
+
+// LoadPEM loads a PEM or DER encoded certificate into a Data struct, when
+// multiple PEM blocks are given the first CERTIFICATE block is used.
+func LoadPEM(b []byte) (*Data, error) {
+	var block *pem.Block
+	var isPEM bool
+
+	rest := b
+	for {
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			return Load(block.Bytes)
+		}
+		isPEM = true
+	}
+
+	if isPEM {
+		return nil, fmt.Errorf("No CERTIFICATE block found in PEM data")
+	}
+
+	// No PEM data found, this should be a DER encoded certificate
+	return Load(b)
+}
+
 // SetIssuer sets the issuer of a certificate
 // TODO: Validate if the correct issuer is given
 func (d *Data) SetIssuer(der []byte) error {
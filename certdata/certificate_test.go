@@ -0,0 +1,55 @@
+package certdata
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"testing"
+)
+
+func TestLoadPEM(t *testing.T) {
+	b, err := ioutil.ReadFile("../testdata/evissues.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(b)
+
+	multi := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte{0x30, 0x00}})
+	multi = append(multi, b...)
+
+	for name, input := range map[string][]byte{"PEM": b, "DER": block.Bytes, "multi-block PEM": multi} {
+		d, err := LoadPEM(input)
+		if err != nil {
+			t.Errorf("Failed to load %s certificate: %s", name, err.Error())
+			continue
+		}
+		if d.Type != "EV" {
+			t.Errorf("Unexpected certificate type for %s got %s, want %s", name, d.Type, "EV")
+		}
+	}
+
+	if _, err := LoadPEM(multi[:len(multi)-len(b)]); err == nil {
+		t.Error("Expected an error for PEM data without a certificate")
+	}
+}
+
+func TestPrecert(t *testing.T) {
+	for file, precert := range map[string]bool{
+		"../testdata/precert.pem":      true,
+		"../testdata/precertfinal.pem": false,
+	} {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := LoadPEM(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Precert != precert {
+			t.Errorf("Unexpected precertificate classification of %s, got %t", file, d.Precert)
+		}
+		if len(d.Cert.UnhandledCriticalExtensions) > 0 {
+			t.Errorf("Unexpected unhandled critical extensions in %s: %v", file, d.Cert.UnhandledCriticalExtensions)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package certdata
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxChainLength is the maximum number of issuers fetched by FetchChain, to
+// stop on loops of cross certificates
+const maxChainLength = 10
+
+// IssuerFetcher retrieves the certificate that issued a certificate, it
+// returns a nil certificate without error when there is nothing to fetch.
+type IssuerFetcher interface {
+	FetchIssuer(ctx context.Context, cert *x509.Certificate) (*x509.Certificate, error)
+}
+
+// IssuerFetcherFunc is an IssuerFetcher implemented by a function
+type IssuerFetcherFunc func(ctx context.Context, cert *x509.Certificate) (*x509.Certificate, error)
+
+// FetchIssuer calls f(ctx, cert)
+func (f IssuerFetcherFunc) FetchIssuer(ctx context.Context, cert *x509.Certificate) (*x509.Certificate, error) {
+	return f(ctx, cert)
+}
+
+// FetchError is the error of fetching an issuer from a single location
+type FetchError struct {
+	URL string
+	Err error
+}
+
+func (e *FetchError) Error() string {
+	return fmt.Sprintf("failed to download issuer certificate from '%s': %s", e.URL, e.Err)
+}
+
+// FetchErrors contains the errors of all locations when no issuer was found
+type FetchErrors []*FetchError
+
+func (e FetchErrors) Error() string {
+	l := make([]string, len(e))
+	for i, err := range e {
+		l[i] = err.Error()
+	}
+	return strings.Join(l, "; ")
+}
+
+// HTTPFetcher downloads issuers from the Authority Info Access CA Issuers URLs
+// of a certificate, the first issuer that can be downloaded is returned.
+type HTTPFetcher struct {
+	// Client is used for the downloads, http.DefaultClient when nil
+	Client *http.Client
+	// Observe is called after each download when set, e.g. for metrics
+	Observe func(url string, d time.Duration, err error)
+}
+
+// FetchIssuer downloads the issuer of cert, the error is a FetchErrors when
+// none of the URLs could be downloaded.
+func (f *HTTPFetcher) FetchIssuer(ctx context.Context, cert *x509.Certificate) (*x509.Certificate, error) {
+	var errs FetchErrors
+	for _, url := range cert.IssuingCertificateURL {
+		start := time.Now()
+		issuer, err := f.download(ctx, url)
+		if f.Observe != nil {
+			f.Observe(url, time.Since(start), err)
+		}
+		if err == nil {
+			return issuer, nil
+		}
+		errs = append(errs, &FetchError{URL: url, Err: err})
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return nil, nil
+}
+
+// download downloads and parses a PEM or DER encoded certificate
+func (f *HTTPFetcher) download(ctx context.Context, url string) (*x509.Certificate, error) {
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode > 399 {
+		return nil, fmt.Errorf("Unexpected response '%s'", resp.Status)
+	}
+
+	derBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// decode pem, if pem
+	block, _ := pem.Decode(derBytes)
+	if block != nil {
+		derBytes = block.Bytes
+	}
+
+	return x509.ParseCertificate(derBytes)
+}
+
+// SignatureError is returned by FetchChain when the signature of a certificate
+// can't be verified with the fetched issuer, the issuer is still used.
+type SignatureError struct {
+	Cert   *x509.Certificate
+	Issuer *x509.Certificate
+	Err    error
+}
+
+func (e *SignatureError) Error() string {
+	return fmt.Sprintf("signature not from fetched issuer: %s", e.Err)
+}
+
+// ChainError contains the errors that occurred while fetching a chain
+type ChainError []error
+
+func (e ChainError) Error() string {
+	l := make([]string, len(e))
+	for i, err := range e {
+		l[i] = err.Error()
+	}
+	return strings.Join(l, "; ")
+}
+
+// FetchChain fetches the issuers of cert until no further issuer is found or a
+// self-signed certificate is reached. The issuers are returned in order, as far
+// as they could be fetched, with a ChainError when errors occurred.
+func FetchChain(ctx context.Context, f IssuerFetcher, cert *x509.Certificate) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	var errs ChainError
+
+	for len(chain) < maxChainLength && !bytes.Equal(cert.RawSubject, cert.RawIssuer) {
+		issuer, err := f.FetchIssuer(ctx, cert)
+		if fe, ok := err.(FetchErrors); ok {
+			for _, e := range fe {
+				errs = append(errs, e)
+			}
+		} else if err != nil {
+			errs = append(errs, err)
+		}
+		if issuer == nil {
+			break
+		}
+
+		// check if the signature on this certificate can be verified with the
+		// fetched issuer certificate
+		if err := cert.CheckSignatureFrom(issuer); err != nil {
+			errs = append(errs, &SignatureError{Cert: cert, Issuer: issuer, Err: err})
+		}
+
+		chain = append(chain, issuer)
+		cert = issuer
+	}
+
+	if len(errs) > 0 {
+		return chain, errs
+	}
+	return chain, nil
+}
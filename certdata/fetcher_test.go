@@ -0,0 +1,77 @@
+package certdata
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPFetcherContext(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var observed int
+	f := &HTTPFetcher{Observe: func(url string, d time.Duration, err error) {
+		observed++
+	}}
+	cert := &x509.Certificate{IssuingCertificateURL: []string{ts.URL}}
+
+	start := time.Now()
+	_, err := f.FetchIssuer(ctx, cert)
+	if fe, ok := err.(FetchErrors); !ok || len(fe) != 1 || fe[0].URL != ts.URL {
+		t.Errorf("Expected a fetch error for %s, got %v", ts.URL, err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("Download was not aborted by the context, took %s", time.Since(start))
+	}
+	if observed != 1 {
+		t.Errorf("Expected 1 observed download, got %d", observed)
+	}
+}
+
+func TestFetchChain(t *testing.T) {
+	b, err := ioutil.ReadFile("../testdata/evissues.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(b)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An issuer from a local store that did not sign the certificate
+	store := IssuerFetcherFunc(func(ctx context.Context, c *x509.Certificate) (*x509.Certificate, error) {
+		if c == cert {
+			return &x509.Certificate{Raw: []byte{1}, RawSubject: []byte{1}, RawIssuer: []byte{1}}, nil
+		}
+		return nil, nil
+	})
+
+	chain, err := FetchChain(context.Background(), store, cert)
+	if len(chain) != 1 {
+		t.Fatalf("Expected a chain of 1 issuer, got %d", len(chain))
+	}
+	errs, ok := err.(ChainError)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("Expected a signature error, got %v", err)
+	}
+	if _, ok := errs[0].(*SignatureError); !ok {
+		t.Errorf("Expected a signature error, got %T", errs[0])
+	}
+}
@@ -0,0 +1,179 @@
+package certdata
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"sort"
+	"time"
+)
+
+// maxPaths is the maximum number of paths returned by BuildPaths
+const maxPaths = 16
+
+// IssuersFetcher is implemented by an IssuerFetcher that can return all
+// candidate issuers of a certificate, e.g. the cross-signed intermediates
+// of multiple AIA URLs.
+type IssuersFetcher interface {
+	FetchIssuers(ctx context.Context, cert *x509.Certificate) ([]*x509.Certificate, error)
+}
+
+// FetchIssuers downloads the issuers of all URLs of cert, the error is a
+// FetchErrors when none of the URLs could be downloaded.
+func (f *HTTPFetcher) FetchIssuers(ctx context.Context, cert *x509.Certificate) ([]*x509.Certificate, error) {
+	var issuers []*x509.Certificate
+	var errs FetchErrors
+	for _, url := range cert.IssuingCertificateURL {
+		start := time.Now()
+		issuer, err := f.download(ctx, url)
+		if f.Observe != nil {
+			f.Observe(url, time.Since(start), err)
+		}
+		if err != nil {
+			errs = append(errs, &FetchError{URL: url, Err: err})
+		} else if !containsCert(issuers, issuer) {
+			issuers = append(issuers, issuer)
+		}
+	}
+
+	if len(issuers) == 0 && len(errs) > 0 {
+		return nil, errs
+	}
+	return issuers, nil
+}
+
+// pathBuilder builds the paths of a certificate, the candidate issuers of
+// each certificate are only fetched once.
+type pathBuilder struct {
+	ctx     context.Context
+	f       IssuerFetcher
+	fetched map[string][]*x509.Certificate
+	paths   [][]*x509.Certificate
+	errs    ChainError
+}
+
+// BuildPaths fetches all candidate issuers of cert and returns the paths from
+// the issuer of cert up to a self-signed certificate. Candidates that don't
+// sign a certificate and loops of cross certificates are skipped. The complete
+// paths are returned first, shortest first, followed by the paths that could
+// not be completed. A ChainError is returned when errors occurred.
+func BuildPaths(ctx context.Context, f IssuerFetcher, cert *x509.Certificate) ([][]*x509.Certificate, error) {
+	b := &pathBuilder{
+		ctx:     ctx,
+		f:       f,
+		fetched: make(map[string][]*x509.Certificate),
+	}
+	b.build(cert, nil)
+
+	sort.SliceStable(b.paths, func(i, j int) bool {
+		ci, cj := isComplete(b.paths[i]), isComplete(b.paths[j])
+		if ci != cj {
+			return ci
+		}
+		return ci && len(b.paths[i]) < len(b.paths[j])
+	})
+
+	if len(b.errs) > 0 {
+		return b.paths, b.errs
+	}
+	return b.paths, nil
+}
+
+// build extends path with the issuers of cert, the last certificate of path
+func (b *pathBuilder) build(cert *x509.Certificate, path []*x509.Certificate) {
+	if len(b.paths) >= maxPaths || b.ctx.Err() != nil {
+		return
+	}
+	if len(path) >= maxChainLength || isSelfSigned(cert) {
+		b.add(path)
+		return
+	}
+
+	var extended bool
+	for _, issuer := range b.issuers(cert) {
+		// Stop on loops of cross certificates
+		if issuer.Equal(cert) || containsCert(path, issuer) {
+			continue
+		}
+		b.build(issuer, append(path[:len(path):len(path)], issuer))
+		extended = true
+	}
+	if !extended {
+		b.add(path)
+	}
+}
+
+// add adds a path to the result, empty paths are ignored
+func (b *pathBuilder) add(path []*x509.Certificate) {
+	if len(path) > 0 && len(b.paths) < maxPaths {
+		b.paths = append(b.paths, path)
+	}
+}
+
+// issuers returns the candidate issuers of cert that signed it. When none of
+// the candidates signed cert they are all returned with a SignatureError, so
+// the issuer can still be checked.
+func (b *pathBuilder) issuers(cert *x509.Certificate) []*x509.Certificate {
+	key := string(cert.Raw)
+	if issuers, ok := b.fetched[key]; ok {
+		return issuers
+	}
+
+	candidates, err := b.fetch(cert)
+	if fe, ok := err.(FetchErrors); ok {
+		for _, e := range fe {
+			b.errs = append(b.errs, e)
+		}
+	} else if err != nil {
+		b.errs = append(b.errs, err)
+	}
+
+	var issuers []*x509.Certificate
+	var sigErrs ChainError
+	for _, c := range candidates {
+		if err := cert.CheckSignatureFrom(c); err != nil {
+			sigErrs = append(sigErrs, &SignatureError{Cert: cert, Issuer: c, Err: err})
+			continue
+		}
+		issuers = append(issuers, c)
+	}
+	if len(issuers) == 0 {
+		issuers = candidates
+		b.errs = append(b.errs, sigErrs...)
+	}
+
+	b.fetched[key] = issuers
+	return issuers
+}
+
+// fetch returns the candidate issuers of cert
+func (b *pathBuilder) fetch(cert *x509.Certificate) ([]*x509.Certificate, error) {
+	if f, ok := b.f.(IssuersFetcher); ok {
+		return f.FetchIssuers(b.ctx, cert)
+	}
+	issuer, err := b.f.FetchIssuer(b.ctx, cert)
+	if issuer == nil {
+		return nil, err
+	}
+	return []*x509.Certificate{issuer}, err
+}
+
+// isSelfSigned returns true if the subject and issuer of cert are equal
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawSubject, cert.RawIssuer)
+}
+
+// isComplete returns true if the path ends with a self-signed certificate
+func isComplete(path []*x509.Certificate) bool {
+	return len(path) > 0 && isSelfSigned(path[len(path)-1])
+}
+
+// containsCert returns true if c is one of the certificates in l
+func containsCert(l []*x509.Certificate, c *x509.Certificate) bool {
+	for _, ic := range l {
+		if ic.Equal(c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package certdata
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// testCA is a generated certificate with its key
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCert creates a CA certificate for key, signed by parent or
+// self-signed when parent is nil
+func newTestCert(t *testing.T, cn string, key *ecdsa.PrivateKey, parent *testCA) *testCA {
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{c, key}
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// mapFetcher returns the candidate issuers by subject common name
+type mapFetcher map[string][]*x509.Certificate
+
+func (f mapFetcher) FetchIssuer(ctx context.Context, cert *x509.Certificate) (*x509.Certificate, error) {
+	if l := f[cert.Issuer.CommonName]; len(l) > 0 {
+		return l[0], nil
+	}
+	return nil, nil
+}
+
+func (f mapFetcher) FetchIssuers(ctx context.Context, cert *x509.Certificate) ([]*x509.Certificate, error) {
+	return f[cert.Issuer.CommonName], nil
+}
+
+func TestBuildPaths(t *testing.T) {
+	oldRoot := newTestCert(t, "Old Root", newTestKey(t), nil)
+	newRootKey := newTestKey(t)
+	newRoot := newTestCert(t, "New Root", newRootKey, nil)
+
+	// The new root cross-signed by the old root, and the old root cross-signed
+	// by the new root to create a loop
+	newRootCross := newTestCert(t, "New Root", newRootKey, oldRoot)
+	oldRootCross := newTestCert(t, "Old Root", oldRoot.key, newRoot)
+
+	intermediate := newTestCert(t, "Intermediate", newTestKey(t), newRoot)
+	leaf := newTestCert(t, "Leaf", newTestKey(t), intermediate)
+
+	f := mapFetcher{
+		"Intermediate": {intermediate.cert},
+		"New Root":     {newRootCross.cert, newRoot.cert},
+		"Old Root":     {oldRootCross.cert, oldRoot.cert},
+	}
+
+	paths, err := BuildPaths(context.Background(), f, leaf.cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Intermediate > New Root, Intermediate > New Root (cross) > Old Root and
+	// Intermediate > New Root (cross) > Old Root (cross) > New Root
+	if len(paths) != 3 {
+		t.Fatalf("Expected 3 paths, got %d", len(paths))
+	}
+	if len(paths[0]) != 2 || !paths[0][1].Equal(newRoot.cert) {
+		t.Errorf("Expected the shortest path to the new root first, got %v", paths[0])
+	}
+	if len(paths[1]) != 3 || !paths[1][2].Equal(oldRoot.cert) {
+		t.Errorf("Expected the cross-signed path to the old root, got %v", paths[1])
+	}
+	if len(paths[2]) != 4 || !paths[2][3].Equal(newRoot.cert) {
+		t.Errorf("Expected the path through both cross certificates, got %v", paths[2])
+	}
+	for _, path := range paths {
+		if !path[0].Equal(intermediate.cert) {
+			t.Errorf("Expected the intermediate as issuer, got %s", path[0].Subject)
+		}
+	}
+}
+
+func TestBuildPathsSignature(t *testing.T) {
+	root := newTestCert(t, "Root", newTestKey(t), nil)
+	other := newTestCert(t, "Root", newTestKey(t), nil)
+	intermediate := newTestCert(t, "Intermediate", newTestKey(t), root)
+
+	// Only the single issuer fetcher interface
+	f := IssuerFetcherFunc(func(ctx context.Context, cert *x509.Certificate) (*x509.Certificate, error) {
+		return other.cert, nil
+	})
+
+	paths, err := BuildPaths(context.Background(), f, intermediate.cert)
+	if len(paths) != 1 || !paths[0][0].Equal(other.cert) {
+		t.Errorf("Expected the fetched issuer to be returned, got %v", paths)
+	}
+	errs, ok := err.(ChainError)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("Expected a signature error, got %v", err)
+	}
+	if _, ok := errs[0].(*SignatureError); !ok {
+		t.Errorf("Expected a signature error, got %T", errs[0])
+	}
+}
@@ -0,0 +1,123 @@
+package certdata
+
+import "time"
+
+// Lifetime is the maximum lifetime of a certificate
+type Lifetime struct {
+	Months int
+	Days   int
+	Desc   string
+}
+
+// NotAfter returns the latest allowed NotAfter for the given NotBefore
+func (l Lifetime) NotAfter(notBefore time.Time) time.Time {
+	return notBefore.AddDate(0, l.Months, l.Days)
+}
+
+// Requirements contains the CA/Browser Forum requirements that were in effect
+// at a point in time. Checks should use the requirements in effect at issuance
+// so certificates are not judged by rules that did not exist yet.
+type Requirements struct {
+	// Lifetime is the maximum lifetime of DV and OV certificates
+	Lifetime Lifetime
+	// EVLifetime is the maximum lifetime of EV certificates
+	EVLifetime Lifetime
+	// MD5 is true when MD5 and MD2 signed certificates were not yet forbidden
+	MD5 bool
+	// SHA1 is true when SHA1 signed certificates may be issued
+	SHA1 bool
+	// SHA1NotAfter is the latest NotAfter of SHA1 signed certificates, zero
+	// when unlimited
+	SHA1NotAfter time.Time
+	// CAA is true when the CAA records must be checked before issuance
+	CAA bool
+	// SerialBits is the minimum number of bits of the serial number, zero when
+	// not required
+	SerialBits int
+	// SerialEntropyBits is the recommended number of bits of unpredictable
+	// random data when SerialBits is not required
+	SerialEntropyBits int
+	// MaxBackdate is the longest time the NotBefore may precede the signing of
+	// the certificate, zero when not limited
+	MaxBackdate time.Duration
+}
+
+// requirementChanges contains the changes of the requirements, sorted by the
+// date the change came into effect.
+var requirementChanges = []struct {
+	from  time.Time
+	apply func(r *Requirements)
+}{
+	{time.Time{}, func(r *Requirements) {
+		r.Lifetime = Lifetime{Months: 60, Desc: "60 months"}
+		r.EVLifetime = Lifetime{Months: 27, Desc: "27 months"}
+		r.MD5 = true
+		r.SHA1 = true
+		r.SerialEntropyBits = 20
+	}},
+	// The Baseline Requirements took effect and only allow the SHA family
+	{time.Date(2012, 7, 1, 0, 0, 0, 0, time.UTC), func(r *Requirements) {
+		r.MD5 = false
+	}},
+	{time.Date(2015, 1, 16, 0, 0, 0, 0, time.UTC), func(r *Requirements) {
+		r.SHA1NotAfter = time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	}},
+	{time.Date(2015, 4, 1, 0, 0, 0, 0, time.UTC), func(r *Requirements) {
+		r.Lifetime = Lifetime{Months: 39, Desc: "39 months"}
+	}},
+	{time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC), func(r *Requirements) {
+		r.SHA1 = false
+	}},
+	// https://cabforum.org/2016/07/08/ballot-164/
+	{time.Date(2016, 9, 30, 0, 0, 0, 0, time.UTC), func(r *Requirements) {
+		r.SerialBits = 64
+		r.SerialEntropyBits = 0
+	}},
+	// CA/Browser Forum ballot 187, CAA checking is mandatory
+	{time.Date(2017, 9, 8, 0, 0, 0, 0, time.UTC), func(r *Requirements) {
+		r.CAA = true
+	}},
+	{time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC), func(r *Requirements) {
+		r.Lifetime = Lifetime{Days: 825, Desc: "825 days"}
+		r.EVLifetime = Lifetime{Days: 825, Desc: "825 days"}
+	}},
+	{time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC), func(r *Requirements) {
+		r.Lifetime = Lifetime{Days: 398, Desc: "398 days"}
+		r.EVLifetime = Lifetime{Days: 398, Desc: "398 days"}
+	}},
+	// https://cabforum.org/2023/03/17/ballot-sc62v2/
+	{time.Date(2023, 9, 15, 0, 0, 0, 0, time.UTC), func(r *Requirements) {
+		r.MaxBackdate = 48 * time.Hour
+	}},
+	// CA/Browser Forum ballot SC-081, the lifetime is reduced in three steps
+	{time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), func(r *Requirements) {
+		r.Lifetime = Lifetime{Days: 200, Desc: "200 days"}
+		r.EVLifetime = Lifetime{Days: 200, Desc: "200 days"}
+	}},
+	{time.Date(2027, 3, 15, 0, 0, 0, 0, time.UTC), func(r *Requirements) {
+		r.Lifetime = Lifetime{Days: 100, Desc: "100 days"}
+		r.EVLifetime = Lifetime{Days: 100, Desc: "100 days"}
+	}},
+	{time.Date(2029, 3, 15, 0, 0, 0, 0, time.UTC), func(r *Requirements) {
+		r.Lifetime = Lifetime{Days: 47, Desc: "47 days"}
+		r.EVLifetime = Lifetime{Days: 47, Desc: "47 days"}
+	}},
+}
+
+// EffectiveRequirements returns the requirements in effect at the given time
+func EffectiveRequirements(t time.Time) Requirements {
+	var r Requirements
+	for _, c := range requirementChanges {
+		if t.Before(c.from) {
+			break
+		}
+		c.apply(&r)
+	}
+	return r
+}
+
+// Requirements returns the requirements in effect when the certificate was
+// issued, based on its NotBefore.
+func (d *Data) Requirements() Requirements {
+	return EffectiveRequirements(d.Cert.NotBefore)
+}
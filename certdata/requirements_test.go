@@ -0,0 +1,37 @@
+package certdata
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEffectiveRequirements(t *testing.T) {
+	for _, test := range []struct {
+		at       time.Time
+		lifetime string
+		sha1     bool
+		caa      bool
+		serial   int
+	}{
+		{time.Date(2014, 6, 1, 0, 0, 0, 0, time.UTC), "60 months", true, false, 0},
+		{time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC), "39 months", false, false, 0},
+		{time.Date(2017, 9, 8, 0, 0, 0, 0, time.UTC), "39 months", false, true, 64},
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "398 days", false, true, 64},
+		{time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), "200 days", false, true, 64},
+		{time.Date(2028, 1, 1, 0, 0, 0, 0, time.UTC), "100 days", false, true, 64},
+		{time.Date(2029, 3, 15, 0, 0, 0, 0, time.UTC), "47 days", false, true, 64},
+	} {
+		r := EffectiveRequirements(test.at)
+		if r.Lifetime.Desc != test.lifetime || r.SHA1 != test.sha1 || r.CAA != test.caa || r.SerialBits != test.serial {
+			t.Errorf("Unexpected requirements at %s: %+v", test.at.Format("2006-01-02"), r)
+		}
+	}
+
+	// The SHA1 sunset applies to certificates issued from 16 Jan 2015
+	if r := EffectiveRequirements(time.Date(2015, 1, 15, 0, 0, 0, 0, time.UTC)); !r.SHA1NotAfter.IsZero() {
+		t.Errorf("Unexpected SHA1 NotAfter %s", r.SHA1NotAfter)
+	}
+	if r := EffectiveRequirements(time.Date(2015, 2, 1, 0, 0, 0, 0, time.UTC)); r.SHA1NotAfter.Year() != 2017 {
+		t.Errorf("Unexpected SHA1 NotAfter %s", r.SHA1NotAfter)
+	}
+}
@@ -8,15 +8,19 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"crypto/sha1"
 	"crypto/x509"
 	"encoding/csv"
+	"encoding/json"
 	"encoding/pem"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
-	"net/http"
+	"net"
 	"os"
+	"os/exec"
 	"runtime"
 	"strings"
 	"time"
@@ -24,10 +28,16 @@ import (
 	"github.com/weyhmueller/certlint/asn1"
 	"github.com/weyhmueller/certlint/certdata"
 	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/checks/certificate/caa"
+	"github.com/weyhmueller/certlint/checks/certificate/psd2"
+	"github.com/weyhmueller/certlint/checks/certificate/revocation"
+	"github.com/weyhmueller/certlint/checks/certificate/smime"
+	"github.com/weyhmueller/certlint/ctlogs"
 	"github.com/weyhmueller/certlint/errors"
 
 	// Import all available checks
 	_ "github.com/weyhmueller/certlint/checks/certificate/all"
+	_ "github.com/weyhmueller/certlint/checks/csr/all"
 	_ "github.com/weyhmueller/certlint/checks/extensions/all"
 
 	"github.com/cloudflare/cfssl/log"
@@ -39,36 +49,163 @@ import (
 
 type testResult struct {
 	Type    string
+	Precert bool
 	Trusted bool
-	Cert    *x509.Certificate
-	Pem     string
-	Der     []byte
-	Errors  *errors.Errors
+	// TrustedBy contains the names of the trust stores the certificate
+	// chains to
+	TrustedBy []string
+	// Chains contains the paths from the issuer to a root that were built
+	// from the downloaded issuers
+	Chains [][]*x509.Certificate
+	Cert   *x509.Certificate
+	Issuer *x509.Certificate
+	Pem    string
+	Der    []byte
+	Source string
+	Errors *errors.Errors
 }
 
-var jobs = make(chan []byte, 100)
+// bulkJob is a certificate to check in bulk mode, source is the file the
+// certificate was read from if it is relevant for the report
+type bulkJob struct {
+	der    []byte
+	source string
+}
+
+var jobs = make(chan bulkJob, 100)
 var results = make(chan testResult, 100)
 var count int64
 var running int
 
+// onlyTypes contains the certificate types to check, all types when empty
+var onlyTypes []string
+
+// minSeverity is the lowest severity of the findings that are reported
+var minSeverity = errors.Unknown
+
+// lintTimeout is the maximum duration of checking a single certificate,
+// including downloading the issuers, no limit when zero
+var lintTimeout time.Duration
+
+// offline disables all network access, issuers are not fetched and the
+// revocation status is not checked
+var offline bool
+
 func main() {
-	var cert = flag.String("cert", "", "Certificate file")
+	var config = flag.String("config", "", "YAML configuration file with default values for all options")
+	var cert = flag.String("cert", "", "Certificate file, - reads from stdin")
 	var bulk = flag.String("bulk", "", "Bulk certificates file")
+	var dir = flag.String("dir", "", "Check all certificate files in this directory tree")
+	var watch = flag.String("watch", "", "Check certificate files as they are written in this directory, until interrupted")
+	var ctLog = flag.String("ct-log", "", "Check the entries of this CT log (url)")
+	var ctStart = flag.Int64("start", 0, "First CT log entry to check with -ct-log")
+	var ctEnd = flag.Int64("end", -1, "Last CT log entry to check with -ct-log, the tree head when negative")
+	var certStream = flag.String("certstream", "", "Check the certificates of this CertStream feed as they are logged (websocket url), until interrupted")
+	var crtsh = flag.String("crtsh", "", "Check all certificates matching this crt.sh query")
+	var crtshBy = flag.String("crtsh-by", "domain", "Type of the -crtsh query (domain, org, caid)")
+	var glob = flag.String("glob", defaultGlobs, "Comma separated file name patterns of certificate files with -dir or -watch")
 	var issuer = flag.String("issuer", "", "Certificate file")
+	var profileName = flag.String("profile", "", "Lint profile selecting the checks and severities (cabf-br, cabf-ev, mozilla, rfc5280-only, private-pki, cabf-smime, etsi-psd2)")
+	var onlyChecks = flag.String("checks", "", "Comma separated names of the checks to run, all checks when empty")
+	var excludeChecks = flag.String("exclude-checks", "", "Comma separated names of the checks not to run")
+	var overrides = flag.String("severity-overrides", "", "JSON file changing the severity of the findings of checks")
+	var caMetadata = flag.String("ca-metadata", "", "JSON file mapping CA subject key identifiers to the CA operator")
+	var csr = flag.String("csr", "", "Certificate signing request file")
+	var connect = flag.String("connect", "", "Check the certificates presented by this TLS server (host:port)")
+	var serverName = flag.String("servername", "", "Server name (SNI) to use with -connect, defaults to the host")
+	var starttlsProto = flag.String("starttls", "", "Use STARTTLS with -connect (smtp, imap, pop3, ldap, xmpp)")
+	var chainFile = flag.String("chain", "", "Chain file, checks all certificates using the file to find the issuers")
+	var p12 = flag.String("p12", "", "PKCS#12 file, checks the certificate and the embedded chain")
+	var p12Password = flag.String("p12-password", "", "Password of the PKCS#12 file")
+	var jsonPath = flag.String("json-path", "", "Read the certificate from this field of a JSON cert file (e.g. $.data.certificate)")
+	var precert = flag.String("precert", "", "Precertificate file to compare with the certificate")
+	var normalizeOut = flag.String("normalize", "", "Write the DER normalized certificate to this file (top level fields only, invalidates the signature)")
+	var includeZlint = flag.Bool("zlint", false, "Include the findings of the zlint binary on the PATH in the results")
+	var compareZlint = flag.Bool("compare-zlint", false, "Compare the findings with the zlint binary on the PATH")
+	var canonicalOut = flag.Bool("canonical", false, "Output a sorted canonical list of findings")
+	var explain = flag.Bool("explain", false, "Show the check description and standards reference for each finding")
+	var listChecks = flag.Bool("list-checks", false, "List the ID, name, standards reference and description of all checks")
+	var concurrentExt = flag.Bool("concurrent-extensions", false, "Check the extensions of a certificate concurrently")
 	var expired = flag.Bool("expired", false, "Test expired certificates")
 	var report = flag.String("report", "report.csv", "Report filename")
+	var reportFormat = flag.String("report-format", "csv", "Report format of bulk results (csv, ndjson, sarif, junit, html, markdown, sqlite, parquet)")
+	var columns = flag.String("columns", strings.Join(defaultColumns, ","), "Comma separated list of CSV report columns")
 	var include = flag.Bool("include", false, "Include certificates in report")
+	var rootsFile = flag.String("roots", "", "PEM file with the only roots to verify chains and trust against, e.g. of a private PKI")
+	var trustStoreList = flag.String("trust-store", "system", "Comma separated trust stores to evaluate trust against (system, mozilla, microsoft, apple or a PEM file)")
+	var trustStoreDir = flag.String("trust-store-dir", "trust-stores", "Directory with the mozilla.pem, microsoft.pem and apple.pem root stores")
 	var revoked = flag.Bool("revoked", false, "Check if certificates are revoked")
+	var offlineMode = flag.Bool("offline", false, "Disable all network access, issuers are only taken from -issuer, -chain or the bulk file")
+	var checkCAA = flag.Bool("caa", false, "Query the CAA records of the dNSName SANs and report the issuers they allow")
+	var caaResolver = flag.String("caa-resolver", "", "DNS server (host:port) for the CAA queries, the system name server when empty")
+	var ctLogList = flag.String("ct-log-list", "", "CT log list (v3 JSON file or url) to verify SCTs against instead of the bundled list")
+	var timeout = flag.Duration("timeout", 0, "Maximum duration of checking a certificate, including issuer downloads and revocation checks (e.g. 30s)")
 	var pprof = flag.String("pprof", "", "Generate pprof profile (cpu,mem,trace)")
+	var useSyslog = flag.Bool("syslog", false, "Send findings to syslog")
+	var syslogFacility = flag.String("syslog-facility", "user", "Syslog facility")
+	var syslogTag = flag.String("syslog-tag", "certlint", "Syslog tag")
+	var onlyType = flag.String("only-type", "", "Only check these certificate types (DV,OV,EV,...)")
+	var minRevocationURLs = flag.Int("min-revocation-urls", revocation.MinURLs, "Minimum number of OCSP or CRL urls for redundancy (0 disables)")
+	var serialFmt = flag.String("serial-format", serialFormat, "Serial number output format (hex, hex-colon, decimal)")
+	var selfContainedBulk = flag.Bool("self-contained", false, "Verify bulk certificates only against the CA certificates in the bulk file")
+	var socket = flag.String("socket", "", "Check certificates received on this unix socket")
+	var minSev = flag.String("min-severity", "", "Only report findings of at least this severity (e.g. warning)")
+	var exitCodes = flag.String("exit-codes", defaultExitCodes, "Comma separated exit codes by the highest severity found (severity=code)")
+	var metricsAddr = flag.String("metrics-addr", "", "Serve Prometheus metrics on /metrics of this address (host:port)")
+	var grpcAddr = flag.String("grpc", "", "Serve the gRPC linting service on this address (host:port)")
+	var format = flag.String("format", "text", "Output format of a single certificate (text, json, sarif, junit, markdown)")
 	var help = flag.Bool("help", false, "Show this help")
 
 	flag.Parse()
 
-	if *help || (len(*cert) < 1 && len(*bulk) < 1) {
+	// Exit based on the highest severity found or a failure, after all deferred
+	// functions have been executed
+	var codes []exitCode
+	defer func() {
+		if code := exitStatus(codes); code != 0 {
+			os.Exit(code)
+		}
+	}()
+
+	// Use the configuration file for the options not given on the command line
+	if len(*config) > 0 {
+		if err := loadConfig(*config, flag.CommandLine); err != nil {
+			fail(err)
+			return
+		}
+	}
+
+	if *listChecks {
+		printChecks()
+		return
+	}
+
+	noInput := len(*cert) < 1 && len(*bulk) < 1 && len(*dir) < 1 && len(*watch) < 1 && len(*ctLog) < 1 && len(*certStream) < 1 && len(*crtsh) < 1 && len(*socket) < 1 && len(*grpcAddr) < 1 && len(*p12) < 1 && len(*csr) < 1 && len(*chainFile) < 1 && len(*connect) < 1
+
+	// Read the certificate from stdin when used at the end of a pipe
+	if noInput && !*help && isPipe(os.Stdin) {
+		*cert = "-"
+		noInput = false
+	}
+
+	if *help || noInput {
 		flag.PrintDefaults()
 		return
 	}
 
+	codes, err := parseExitCodes(*exitCodes)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	if len(*minSev) > 0 {
+		if minSeverity, err = errors.ParsePriority(*minSev); err != nil {
+			fail(err)
+			return
+		}
+	}
+
 	// Is any profiling requested?
 	switch *pprof {
 	case "cpu":
@@ -84,37 +221,545 @@ func main() {
 	// Prevent CloudFlare informational log messages
 	log.Level = log.LevelError
 
-	// Start the bulk checking logic to parse a pem file with more certificates and
-	// save the results to a csv file.
+	revocation.MinURLs = *minRevocationURLs
+	caa.Enabled = *checkCAA
+	caa.Resolver = *caaResolver
+	lintTimeout = *timeout
+
+	// Refuse the options that can't work without network access
+	if *offlineMode {
+		switch {
+		case *revoked:
+			fail("-revoked can't be used with -offline")
+			return
+		case *checkCAA:
+			fail("-caa can't be used with -offline")
+			return
+		case len(*ctLog) > 0, len(*certStream) > 0, len(*crtsh) > 0, len(*connect) > 0:
+			fail("-ct-log, -certstream, -crtsh and -connect can't be used with -offline")
+			return
+		}
+		offline = true
+	}
+
+	// Verify the SCTs against a more recent log list than the bundled list
+	if len(*ctLogList) > 0 {
+		l, err := loadCTLogList(*ctLogList)
+		if err != nil {
+			fail(err)
+			return
+		}
+		ctlogs.SetDefault(l)
+	}
+	checks.ConcurrentExtensions = *concurrentExt
+
+	var lp lintProfile
+	if len(*profileName) > 0 {
+		if lp, err = getProfile(*profileName); err != nil {
+			fail(err)
+			return
+		}
+		psd2.Required = lp.RequirePSD2
+		smime.Required = lp.RequireSMIME
+	}
+
+	if len(*rootsFile) > 0 {
+		if flagSet("trust-store") {
+			fail("-roots can't be used with -trust-store")
+			return
+		}
+		if err := useRoots(*rootsFile); err != nil {
+			fail(err)
+			return
+		}
+	} else if trustStores, err = loadTrustStores(*trustStoreList, *trustStoreDir); err != nil {
+		fail(err)
+		return
+	}
+
+	if err := checks.Select(splitList(*onlyChecks), append(lp.Exclude, splitList(*excludeChecks)...)); err != nil {
+		fail(err)
+		return
+	}
+
+	switch *format {
+	case "text", "json", "sarif", "junit", "markdown":
+	default:
+		fail(fmt.Sprintf("Unknown output format '%s'", *format))
+		return
+	}
+	switch *reportFormat {
+	case "csv", "ndjson", "sarif", "junit", "html", "markdown", "sqlite", "parquet":
+	default:
+		fail(fmt.Sprintf("Unknown report format '%s'", *reportFormat))
+		return
+	}
+
+	// Refuse the options that are only part of the CSV report
+	if *reportFormat != "csv" {
+		switch {
+		case *revoked:
+			fail("-revoked can only be used with -report-format csv")
+			return
+		case flagSet("columns"):
+			fail("-columns can only be used with -report-format csv")
+			return
+		}
+	}
+
+	cols, err := parseColumns(*columns)
+	if err != nil {
+		fail(err)
+		return
+	}
+	csvColumns = cols
+
+	// Include the file or log entry of each certificate in the default report columns
+	if (len(*dir) > 0 || len(*watch) > 0 || len(*ctLog) > 0 || len(*certStream) > 0 || len(*crtsh) > 0) && !flagSet("columns") {
+		csvColumns = append([]string{"source"}, csvColumns...)
+	}
+
+	// Run zlint alongside our own checks, the report shows which linter
+	// reported each finding
+	if *includeZlint {
+		if _, err := exec.LookPath("zlint"); err != nil {
+			fail(err)
+			return
+		}
+		withZlint = true
+
+		if !flagSet("columns") {
+			var cols []string
+			for _, c := range csvColumns {
+				if c == "severity" {
+					cols = append(cols, "linter")
+				}
+				cols = append(cols, c)
+			}
+			csvColumns = cols
+		}
+	}
+
+	globs, err := parseGlobs(*glob)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	switch *serialFmt {
+	case "hex", "hex-colon", "decimal":
+		serialFormat = *serialFmt
+	default:
+		fail(fmt.Sprintf("Unknown serial format '%s'", *serialFmt))
+		return
+	}
+
+	// Change the severity of findings, the overrides of the command line take
+	// precedence over the profile
+	if len(*overrides) > 0 {
+		l, err := loadSeverityOverrides(*overrides)
+		if err != nil {
+			fail(err)
+			return
+		}
+		severityOverrides = l
+	}
+	severityOverrides = append(severityOverrides, lp.Overrides...)
+
+	// Annotate results with the operator of the issuing CA
+	if len(*caMetadata) > 0 {
+		m, err := loadCAMetadata(*caMetadata)
+		if err != nil {
+			fail(err)
+			return
+		}
+		caOperators = m
+	}
+
+	if len(*onlyType) > 0 {
+		onlyTypes = strings.Split(*onlyType, ",")
+	} else if len(lp.Types) > 0 {
+		onlyTypes = lp.Types
+	}
+
+	// Send findings to syslog in addition to the normal output
+	if *useSyslog {
+		l, err := newSyslog(*syslogFacility, *syslogTag)
+		if err != nil {
+			fail(err)
+			return
+		}
+		findingLog = l
+	}
+
+	// Expose the metrics of the bulk and server modes
+	if len(*metricsAddr) > 0 {
+		if err := serveMetrics(*metricsAddr); err != nil {
+			fail(err)
+			return
+		}
+	}
+
+	// Check all certificates received on a unix socket
+	if len(*socket) > 0 {
+		l, err := net.Listen("unix", *socket)
+		if err != nil {
+			fail(err)
+			return
+		}
+		defer l.Close()
+		serveSocket(l, *expired)
+		return
+	}
+
+	// Serve the gRPC linting service, see api/certlint.proto
+	if len(*grpcAddr) > 0 {
+		l, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			fail(err)
+			return
+		}
+		if err = serveGRPC(l, *expired); err != nil {
+			fail(err)
+		}
+		return
+	}
+
+	// Check a certificate signing request before issuance
+	if len(*csr) > 0 {
+		der, err := readCSR(*csr)
+		if err != nil {
+			fail(err)
+			return
+		}
+		result := doCSR(der)
+		result.Errors = reportFindings(result.Errors)
+
+		switch *format {
+		case "json":
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(newJSONResult(result)); err != nil {
+				fail(err)
+			}
+		case "text":
+			if *canonicalOut {
+				for _, l := range canonical(result) {
+					fmt.Println(l)
+				}
+				return
+			}
+			printResult(result, der, *explain, false)
+		default:
+			fail(fmt.Sprintf("Output format '%s' is not supported for certificate signing requests", *format))
+		}
+		return
+	}
+
+	// Start the bulk checking logic to parse a pem file with more certificates, all
+	// certificate files in a directory tree, the files written in a watched
+	// directory, the entries of a CT log, a CertStream feed or the certificates
+	// matching a crt.sh query, and save the results to a report.
 	running = 0
-	if len(*bulk) > 0 {
+	if len(*bulk) > 0 || len(*dir) > 0 || len(*watch) > 0 || len(*ctLog) > 0 || len(*certStream) > 0 || len(*crtsh) > 0 {
+		if *selfContainedBulk && len(*bulk) > 0 {
+			b, err := loadBundle(*bulk)
+			if err != nil {
+				fail(err)
+				return
+			}
+			selfContained = b
+		}
+
 		for i := 1; i <= runtime.NumCPU(); i++ {
 			go runBulk(*expired)
 		}
-		go doBulk(*bulk)
-		saveResults(*report, *include, *revoked)
+		source := *bulk
+		switch {
+		case len(*crtsh) > 0:
+			source = *crtsh
+			go doCrtSh(*crtsh, *crtshBy, *expired)
+		case len(*certStream) > 0:
+			source = *certStream
+			go doCertStream(*certStream, interrupted())
+		case len(*ctLog) > 0:
+			source = *ctLog
+			go doCTLog(*ctLog, *ctStart, *ctEnd)
+		case len(*watch) > 0:
+			source = *watch
+			go doWatch(*watch, globs, interrupted())
+		case len(*dir) > 0:
+			source = *dir
+			go doDir(*dir, globs)
+		default:
+			go doBulk(*bulk)
+		}
+		switch *reportFormat {
+		case "ndjson":
+			err = saveNDJSON(*report, *include)
+		case "sarif":
+			err = saveSARIF(*report, source)
+		case "junit":
+			err = saveJUnit(*report)
+		case "html":
+			err = saveHTML(*report)
+		case "markdown":
+			err = saveMarkdown(*report)
+		case "sqlite":
+			err = saveSQLite(*report)
+		case "parquet":
+			err = saveParquet(*report)
+		default:
+			err = saveResults(*report, *include, *revoked)
+		}
+		if err != nil {
+			fail(err)
+		}
 		return
 	} else {
 
-	    // Check one certificate and print results on screen
-	    der := getCertificate(*cert)
-	    result := do(nil, der, issuer, *expired, true)
-
-	    fmt.Println("Certificate Type:", result.Type)
-	    if result.Errors != nil {
-	  	    for _, err := range result.Errors.List() {
-			    fmt.Println(err)
-		    }
-	    }
-    }
+		// Check one certificate and print results on screen, for a TLS server,
+		// chain file or PKCS#12 archive all certificates are checked.
+		var ders [][]byte
+		switch {
+		case len(*connect) > 0:
+			var err error
+			if ders, err = fetchChain(*connect, *serverName, *starttlsProto); err != nil {
+				fail(err)
+				return
+			}
+			if len(ders) == 0 {
+				fail(fmt.Sprintf("%s: no certificates presented", *connect))
+				return
+			}
+			chainBundle = newChainBundle(ders)
+		case len(*chainFile) > 0:
+			f, err := os.Open(*chainFile)
+			if err != nil {
+				fail(err)
+				return
+			}
+			err = scanCertificates(f, func(der, _ []byte, err error) {
+				if der != nil {
+					ders = append(ders, der)
+				}
+			})
+			f.Close()
+			if err != nil {
+				fail(err)
+				return
+			}
+			if len(ders) == 0 {
+				fail(fmt.Sprintf("%s: no certificates found", *chainFile))
+				return
+			}
+			chainBundle = newChainBundle(ders)
+		case len(*p12) > 0:
+			var err error
+			if ders, err = readPKCS12(*p12, *p12Password); err != nil {
+				fail(err)
+				return
+			}
+		case len(*jsonPath) > 0:
+			der, err := getJSONCertificate(*cert, *jsonPath)
+			if err != nil {
+				fail(err)
+				return
+			}
+			ders = [][]byte{der}
+		default:
+			der, err := readCertificate(*cert)
+			if err != nil {
+				fail(err)
+				return
+			}
+			ders = [][]byte{der}
+		}
+
+		var checked []testResult
+		for _, der := range ders {
+			checked = append(checked, do(nil, der, issuer, *expired, true))
+		}
+		result, der := checked[0], ders[0]
+
+		// Verify the certificate has been issued from the given precertificate
+		if len(*precert) > 0 {
+			result.Errors.Append(reportFindings(checkPrecert(getCertificate(*precert), der)))
+		}
+
+		// Write the certificate using a strict DER encoding
+		if len(*normalizeOut) > 0 {
+			result.Errors.Append(reportFindings(writeNormalized(*normalizeOut, der)))
+		}
+
+		// Output a stable list for golden file comparison only
+		if *canonicalOut {
+			for _, result := range checked {
+				for _, l := range canonical(result) {
+					fmt.Println(l)
+				}
+			}
+			return
+		}
+
+		// Structured report formats of these results
+		if *format == "sarif" || *format == "junit" || *format == "markdown" {
+			ch := make(chan testResult, len(checked))
+			for _, result := range checked {
+				ch <- result
+			}
+			close(ch)
+
+			var err error
+			switch *format {
+			case "sarif":
+				uri := *cert
+				if len(*p12) > 0 {
+					uri = *p12
+				} else if len(*chainFile) > 0 {
+					uri = *chainFile
+				} else if len(*connect) > 0 {
+					uri = *connect
+				}
+				err = writeSARIF(os.Stdout, ch, uri)
+			case "junit":
+				err = writeJUnit(os.Stdout, ch)
+			case "markdown":
+				err = writeMarkdown(os.Stdout, ch)
+			}
+			if err != nil {
+				fail(err)
+			}
+			return
+		}
+		if *format == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+
+			// Only output a list when the certificates of a file are checked
+			var v interface{} = newJSONResult(result)
+			if len(checked) > 1 {
+				var list []jsonResult
+				for _, result := range checked {
+					list = append(list, newJSONResult(result))
+				}
+				v = list
+			}
+			if err := enc.Encode(v); err != nil {
+				fail(err)
+			}
+			return
+		}
+
+		for i, result := range checked {
+			if len(checked) > 1 {
+				if i > 0 {
+					fmt.Println()
+				}
+				if result.Cert != nil {
+					fmt.Println("Subject:", result.Cert.Subject)
+				}
+			}
+			printResult(result, ders[i], *explain, *compareZlint)
+		}
+	}
+}
+
+// printResult outputs the findings of a single certificate on screen
+func printResult(result testResult, der []byte, explain, compareZlint bool) {
+	fmt.Println("Certificate Type:", result.Type)
+	if result.Cert != nil {
+		fmt.Println("Serial Number:", formatSerial(result.Cert.SerialNumber))
+	}
+	if result.Errors != nil {
+		for _, err := range result.Errors.List() {
+			msg := err.Error()
+			if n := err.Count(); n > 1 {
+				msg = fmt.Sprintf("%s (%d times)", msg, n)
+			}
+			m, ok := checks.Lookup(err.Check())
+			if !explain || !ok {
+				fmt.Println(msg)
+				continue
+			}
+			if len(m.Citation) > 0 {
+				fmt.Printf("%s (%s)\n", msg, m.Citation)
+			} else {
+				fmt.Println(msg)
+			}
+			fmt.Printf("    %s: %s\n", strings.TrimSpace(err.Code()+" "+m.ID), m.Description)
+		}
+	}
+
+	// Show the differences with the findings of zlint
+	if compareZlint {
+		zlint, err := runZlint(der)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		var findings []string
+		for _, err := range result.Errors.List() {
+			if err.Check() != zlintCheck {
+				findings = append(findings, err.Error())
+			}
+		}
+		diff := diffZlint(findings, zlint)
+
+		fmt.Println("\nReported by both certlint and zlint:")
+		for _, f := range diff.Both {
+			fmt.Println(" ", f)
+		}
+		fmt.Println("Only reported by certlint:")
+		for _, f := range diff.CertlintOnly {
+			fmt.Println(" ", f)
+		}
+		fmt.Println("Only reported by zlint:")
+		for _, f := range diff.ZlintOnly {
+			fmt.Println(" ", f)
+		}
+	}
+}
+
+// printChecks outputs all registered checks on screen
+func printChecks() {
+	for _, c := range checks.All() {
+		fmt.Printf("%s: %s (%s)\n", c.ID, c.Name, c.Citation)
+		fmt.Printf("    %s\n", c.Description)
+	}
 }
 
 // do performs the checks on the der encoding and the actual certificate, if exp
 // is set true it will also check expired certificates.
 func do(icaCache *lru.Cache, der []byte, issuer *string, exp, rtrn bool) testResult {
+	return lint(icaCache, der, "", issuer, exp, rtrn)
+}
+
+// lint performs the checks of do and includes the source file of the
+// certificate in the result, within lintTimeout when set.
+func lint(icaCache *lru.Cache, der []byte, source string, issuer *string, exp, rtrn bool) testResult {
+	ctx, cancel := lintContext()
+	defer cancel()
+	return lintBundle(ctx, icaCache, der, source, issuer, chainBundle, exp, rtrn)
+}
+
+// lintContext returns the context to check a single certificate, which is done
+// after lintTimeout when set.
+func lintContext() (context.Context, context.CancelFunc) {
+	if lintTimeout > 0 {
+		return context.WithTimeout(context.Background(), lintTimeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// lintBundle performs the checks of lint, the issuers are taken from the
+// bundle when it is not nil. Downloading issuers and the checks are aborted
+// when the context is done.
+func lintBundle(ctx context.Context, icaCache *lru.Cache, der []byte, source string, issuer *string, bundle *certBundle, exp, rtrn bool) testResult {
 	// use a local cache to prevent that we need to wait on a local
 	var result testResult
-	result.Errors = errors.New(nil)
+	result.Errors = errors.New(&errors.Config{Deduplicate: true})
+	result.Source = source
 
 	// Include der in results for debugging
 	result.Der = der
@@ -126,33 +771,71 @@ func do(icaCache *lru.Cache, der []byte, issuer *string, exp, rtrn bool) testRes
 	// Load certificate
 	d, err := certdata.Load(der)
 	if err != nil {
-		result.Errors.Err(err.Error())
+		result.Errors.Err("Failed to parse certificate: %s", err)
 	} else {
 		result.Trusted = true
 		result.Cert = d.Cert
 		result.Type = d.Type
+		result.Precert = d.Precert
 
-		// Indication to not check this type of certificate
-		if d.Type == "-" {
-			return result
-		}
-
-		// Check if we need to skip expied certificates
-		if !exp && d.Cert.NotAfter.Before(time.Now()) {
+		// Skip certificates of a type that is not checked ("-"), types we have not
+		// been asked to check and expired certificates if requested
+		if d.Type == "-" || !checkType(d.Type) || (!exp && d.Cert.NotAfter.Before(time.Now())) {
+			result.Errors = overrideSeverity(result.Errors).AtLeast(minSeverity)
 			return result
 		}
 
 		var pool *x509.CertPool
+		var chainSkipped bool
 		type issuerCache struct {
-			Trusted bool
-			Issuer  *x509.Certificate
-			Pool    *x509.CertPool
+			Trusted   bool
+			TrustedBy []string
+			Chains    [][]*x509.Certificate
+			Issuer    *x509.Certificate
+			Pool      *x509.CertPool
 		}
 
 		// If we have the issuer certificate verify the raw issuer struct and signatures
 		if issuer != nil && len(*issuer) > 0 {
-			d.SetIssuer(getCertificate(*issuer))
-			pool.AddCert(d.Issuer)
+			if err := d.SetIssuer(getCertificate(*issuer)); err != nil {
+				result.Errors.Err("Failed to load issuer certificate: %s", err.Error())
+			} else {
+				pool = x509.NewCertPool()
+				pool.AddCert(d.Issuer)
+			}
+		} else if selfContained != nil {
+			// Only use the CA certificates from the bulk file, without network access
+			chains, err := selfContained.verify(d.Cert)
+			if err != nil {
+				result.Trusted = false
+				result.Errors.Err("Certificate does not chain to a root in the bulk file: %s", err.Error())
+			} else if len(chains[0]) > 1 {
+				d.Issuer = chains[0][1]
+			} else {
+				d.Issuer = chains[0][0]
+			}
+		} else if bundle != nil {
+			// Use the issuers from the chain file instead of fetching them
+			result.TrustedBy = trustedBy(d.Cert, x509.VerifyOptions{
+				Intermediates: bundle.intermediates,
+				CurrentTime:   d.Cert.NotBefore,
+				KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+			})
+			result.Trusted = len(result.TrustedBy) > 0
+
+			chains, err := bundle.verify(d.Cert)
+			if err != nil {
+				d.Issuer = bundle.issuer(d.Cert)
+			} else if len(chains[0]) > 1 {
+				d.Issuer = chains[0][1]
+			} else {
+				d.Issuer = chains[0][0]
+			}
+		} else if offline {
+			// Without network access the chain can't be completed, the checks
+			// comparing the certificate with its issuer are skipped
+			chainSkipped = true
+			result.Errors.Info("Issuer not fetched in offline mode, chain checks skipped")
 		} else {
 			var key string
 
@@ -181,108 +864,202 @@ func do(icaCache *lru.Cache, der []byte, issuer *string, exp, rtrn bool) testRes
 			if ok {
 				ic := cache.(issuerCache)
 				result.Trusted = ic.Trusted
+				result.TrustedBy = ic.TrustedBy
+				result.Chains = ic.Chains
 				d.Issuer = ic.Issuer
 				pool = ic.Pool
 
 			} else {
 				var e = errors.New(nil)
-				d.Issuer, pool, e = getIssuerPool(d.Cert)
+				d.Issuer, pool, result.Chains, e = getIssuerPool(ctx, d.Cert)
 				result.Errors.Append(e)
 
-				// Check if this is a publicly trusted certificate
-				opts := x509.VerifyOptions{
+				// Check if this is a trusted certificate in the selected trust stores
+				result.TrustedBy = trustedBy(d.Cert, x509.VerifyOptions{
 					Intermediates: pool,
 					KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
-				}
-				if _, err = d.Cert.Verify(opts); err != nil {
-					result.Trusted = false
-				}
+				})
+				result.Trusted = len(result.TrustedBy) > 0
 
 				// Save pool in cache
 				if pool != nil && icaCache != nil {
-					icaCache.Add(key, issuerCache{result.Trusted, d.Issuer, pool})
+					icaCache.Add(key, issuerCache{result.Trusted, result.TrustedBy, result.Chains, d.Issuer, pool})
 				}
 			}
 		}
 
-		if !result.Trusted {
+		if chainSkipped {
+			result.Trusted = false
+		} else if !result.Trusted {
 			result.Errors.Err("Failed to verify chain for %s", d.Cert.Issuer.CommonName)
 		}
 
-		if d.Issuer == nil {
-			fmt.Printf("Incomplete chain for %s %s %x %v\n", d.Cert.Issuer.CommonName, d.Cert.Subject.CommonName, d.Cert.SerialNumber, result.Errors)
+		result.Issuer = d.Issuer
+		if d.Issuer == nil && !chainSkipped {
+			fmt.Fprintf(os.Stderr, "Incomplete chain for %s %s %x %v\n", d.Cert.Issuer.CommonName, d.Cert.Subject.CommonName, d.Cert.SerialNumber, result.Errors)
 		}
 
 		// Check against errors
-		result.Errors.Append(checks.Certificate.Check(d))
+		result.Errors.Append(checks.Certificate.CheckContext(ctx, d))
+
+		// Include the findings of zlint for this certificate
+		if withZlint {
+			result.Errors.Append(zlintErrors(der))
+		}
 	}
 
+	result.Errors = overrideSeverity(result.Errors)
 	if len(result.Errors.List(errors.Notice, errors.Warning, errors.Error, errors.Alert, errors.Critical, errors.Emergency)) == 0 {
 		result.Errors.Info("This Certificate is acceptable")
 	}
 
-	// In batch mode we want to queue results
-	if !rtrn && len(result.Errors.List()) > 0 {
+	// Drop the findings below the requested severity
+	result.Errors = result.Errors.AtLeast(minSeverity)
+
+	if findingLog != nil {
+		logFindings(findingLog, result)
+	}
+	countResult(result)
+	notePriority(result.Errors)
+
+	// In batch mode we want to queue results, also those without findings as
+	// the aggregates need every certificate
+	if !rtrn {
 		results <- result
 	}
 	return result
 }
 
-func doBulk(bulk string) {
-	var pemCert []byte
+// splitList returns the items of a comma separated list, nil when s is empty
+func splitList(s string) []string {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// checkType returns true if certificates of type t need to be checked
+func checkType(t string) bool {
+	if len(onlyTypes) == 0 {
+		return true
+	}
+	for _, ot := range onlyTypes {
+		if strings.EqualFold(strings.TrimSpace(ot), t) {
+			return true
+		}
+	}
+	return false
+}
 
+func doBulk(bulk string) {
 	f, err := os.Open(bulk)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
 
+	scanCertificates(f, func(der, pemCert []byte, err error) {
+		if der != nil {
+			count++
+			jobs <- bulkJob{der: der}
+			return
+		}
+
+		var e = errors.New(nil)
+		if err != nil {
+			e.Err("Failed to parse PKCS#7 bundle: %s", err)
+		}
+
+		notePriority(e)
+		results <- testResult{
+			Cert:   nil,
+			Pem:    string(pemCert),
+			Errors: e,
+		}
+	})
+
+	fmt.Printf("Checked %d certificates\n", count)
+	close(jobs)
+}
+
+// scanCertificates calls fn for every certificate in a bulk file of PEM
+// encoded certificates and PKCS#7 bundles, in a DER encoded PKCS#7 bundle or
+// a DER encoded certificate.
+// The DER bytes are nil when a PEM block could not be decoded.
+func scanCertificates(r io.Reader, fn func(der, pemCert []byte, err error)) error {
+	br := bufio.NewReader(r)
+
+	// A DER encoded PKCS#7 bundle or certificate is small enough to read at
+	// once, it starts with a SEQUENCE using the long form length encoding.
+	if b, _ := br.Peek(2); len(b) == 2 && b[0] == 0x30 && b[1] > 0x80 {
+		der, err := ioutil.ReadAll(br)
+		if err != nil {
+			return err
+		}
+		if !isPKCS7(der) {
+			fn(der, nil, nil)
+			return nil
+		}
+		certs, err := parsePKCS7(der)
+		if err != nil {
+			fn(nil, nil, err)
+			return nil
+		}
+		for _, c := range certs {
+			fn(c, nil, nil)
+		}
+		return nil
+	}
+
 	// Unfortunately pem.Decode can't use a io.Reader but exspects a byte array
 	// the files we want to support are to big to load in memory.
-	scanner := bufio.NewScanner(f)
+	var pemCert []byte
+	scanner := bufio.NewScanner(br)
 	for scanner.Scan() {
 		line := scanner.Bytes()
 
-		// "-BEGIN CERTIFICATE-"
-		if bytes.Contains(line, []byte{0x2d, 0x42, 0x45, 0x47, 0x49, 0x4e, 0x20, 0x43, 0x45, 0x52, 0x54, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x45, 0x2d}) {
+		if bytes.Contains(line, []byte("-BEGIN CERTIFICATE-")) || bytes.Contains(line, []byte("-BEGIN PKCS7-")) {
 			pemCert = []byte{}
 		}
 
-		pemCert = append(pemCert, []byte{0xa}...)
+		pemCert = append(pemCert, '\n')
 		pemCert = append(pemCert, line...)
 
-		// Check last line for "-END CERTIFICATE-"
-		if bytes.Contains(line, []byte{0x2d, 0x45, 0x4e, 0x44, 0x20, 0x43, 0x45, 0x52, 0x54, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x45, 0x2d}) {
+		// Check last line for the end of the PEM block
+		if bytes.Contains(line, []byte("-END CERTIFICATE-")) {
 			block, _ := pem.Decode(pemCert)
 			if block != nil {
-				count++
-				jobs <- block.Bytes
+				fn(block.Bytes, pemCert, nil)
 			} else {
-				var e = errors.New(nil)
-				if err != nil {
-					e.Err(err.Error())
-				}
-
-				results <- testResult{
-					Cert:   nil,
-					Pem:    string(pemCert),
-					Errors: e,
-				}
+				fn(nil, pemCert, nil)
+			}
+		} else if bytes.Contains(line, []byte("-END PKCS7-")) {
+			block, _ := pem.Decode(pemCert)
+			if block == nil {
+				fn(nil, pemCert, nil)
+				continue
+			}
+			certs, err := parsePKCS7(block.Bytes)
+			if err != nil {
+				fn(nil, pemCert, err)
+				continue
+			}
+			for _, c := range certs {
+				fn(c, pemCert, nil)
 			}
 		}
 	}
 
-	fmt.Printf("Checked %d certificates\n", count)
-	close(jobs)
+	return scanner.Err()
 }
 
 func runBulk(exp bool) {
 	running+=1
 	var icaCache = lru.New(200)
 	for {
-		der, more := <-jobs
+		job, more := <-jobs
 		if more {
-			do(icaCache, der, nil, exp, false)
+			lint(icaCache, job.der, job.source, nil, exp, false)
 		} else {
 			break
 		}
@@ -294,194 +1071,207 @@ func runBulk(exp bool) {
 func saveResults(filename string, include, revoked bool) error {
 	file, err := os.Create(filename)
 	if err != nil {
-		fmt.Println(err)
 		return err
 	}
 	defer file.Close()
 
 	writer := csv.NewWriter(file)
 	writer.UseCRLF = true
-	writer.Write([]string{"Number","Issuer", "CN", "O", "Serial", "NotBefore", "NotAfter", "Type", "Severity", "Error", "Revoked", "Cert"})
+	writer.Write(csvHeader(csvColumns))
 	writer.Flush()
 	counter := 0
+	aggregates := newAggregates()
 
-	for {
-		r, more := <-results
-		if more {
-			for _, e := range r.Errors.List() {
-				var columns []string
-				if r.Cert != nil {
-					columns = []string{
-						fmt.Sprintf("%d",counter),
-						fmt.Sprintf("%s, %s", r.Cert.Issuer.CommonName, r.Cert.Issuer.Organization),
-						r.Cert.Subject.CommonName,
-						strings.Join(r.Cert.Subject.Organization, ", "),
-						fmt.Sprintf("%x", r.Cert.SerialNumber),
-						r.Cert.NotBefore.Format("2006-01-02"),
-						r.Cert.NotAfter.Format("2006-01-02"),
-						r.Type,
-						strings.ToUpper(e.Priority().String()),
-						e.Error(),
-					}
-
-					// Check if certificate is revoked when indicated
-					if revoked {
-						//running +=1
-						if isRevoked, ok := revoke.VerifyCertificate(r.Cert); ok {
-							columns = append(columns, fmt.Sprintf("%t", isRevoked))
-						} else {
-							columns = append(columns, "failed")
-						}
-						//running -=1
-						//fmt.Printf("Running Threads: %d \n", running)
-
-						if running == 0 {
-							test, ok  := <-results
-							if ok {
-								fmt.Printf("Got: '%#v' state: '%#v'\n", test, ok)
-								//close(results)
-							}
-						}
-					} else {
-						columns = append(columns, "")
-					}
-
-					// Do we need to include the certificate
-					if include {
-						columns = append(columns, string(pem.EncodeToMemory(&pem.Block{
-							Type:  "CERTIFICATE",
-							Bytes: r.Der,
-						})))
-					} else {
-						columns = append(columns, "")
-					}
-
-				} else {
-					columns = []string{fmt.Sprintf("%d",counter), "", "", "", "", "", "", "",strings.ToUpper(e.Priority().String()), e.Error(), "", r.Pem}
-				}
+	for r := range results {
+		for _, a := range aggregates {
+			a.Add(r)
+		}
 
-				err := writer.Write(columns)
-				if err != nil {
-					fmt.Println(err)
-					continue
-				}
+		c := &csvCertificate{testResult: r, Number: counter, Include: include}
 
-				writer.Flush()
+		// Check if certificate is revoked when indicated
+		if revoked && r.Cert != nil {
+			if isRevoked, ok := verifyRevoked(r.Cert); ok {
+				c.Revoked = fmt.Sprintf("%t", isRevoked)
+			} else {
+				c.Revoked = "failed"
 			}
-			counter++
+		}
 
-			if running == 0 {
-				//val, ok := <-results
-				//fmt.Printf("Got: '%#v' state: '%#v'\n", val, ok)
-				//if ok {
-					//if !revoked {close(results)}
-				//}
+		for _, e := range r.Errors.List() {
+			err := writer.Write(csvRow(csvColumns, c, e))
+			if err != nil {
+				fmt.Println(err)
+				continue
 			}
-		} else {
-			break
+
+			writer.Flush()
 		}
+		counter++
+	}
+
+	// Report the findings over all certificates in this bulk run
+	summary := checkAggregates(aggregates)
+	for _, e := range summary.List() {
+		fmt.Println(e)
+		writer.Write(csvRow(csvColumns, nil, e))
 	}
+	writer.Flush()
+
 	return nil
 }
 
 // getCertificate reads a single certificate from disk
 func getCertificate(file string) []byte {
-	derBytes, err := ioutil.ReadFile(file)
+	der, err := readCertificate(file)
 	if err != nil {
 		fmt.Println(err)
 		return nil
 	}
-	// decode pem
-	block, _ := pem.Decode(derBytes)
-	if block != nil {
-		derBytes = block.Bytes
-	}
-	return derBytes
+	return der
 }
 
-func getIssuerPool(cert *x509.Certificate) (*x509.Certificate, *x509.CertPool, *errors.Errors) {
-	var e = errors.New(nil)
-	var issuer *x509.Certificate
+// stdin is read when - is used as filename
+var stdin io.Reader = os.Stdin
 
-	pool := x509.NewCertPool()
-	var i int
-	for len(cert.IssuingCertificateURL) > 0 {
-		ic, err := getIssuer(cert)
-		e.Append(err)
-		if ic == nil {
-			break
-		}
-
-		// add certificate to pool
-		pool.AddCert(ic)
+// readFile returns the contents of file, or of stdin if file is -
+func readFile(file string) ([]byte, error) {
+	if file == "-" {
+		return ioutil.ReadAll(stdin)
+	}
+	return ioutil.ReadFile(file)
+}
 
-		// issuer of end-entity certificate
-		if i == 0 {
-			issuer = ic
+// flagSet reports if the flag name has been set on the command line
+func flagSet(name string) bool {
+	var set bool
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
 		}
+	})
+	return set
+}
 
-		// download the issuer of the issuer certificate
-		cert = ic
-		i++
-	}
+// isPipe reports if f is a pipe or file instead of a terminal
+func isPipe(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice == 0
+}
 
-	return issuer, pool, e
+// readCertificate reads a PEM or DER encoded certificate from file
+func readCertificate(file string) ([]byte, error) {
+	b, err := readFile(file)
+	if err != nil {
+		return nil, err
+	}
+	der, err := decodeCertificate(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", file, err.Error())
+	}
+	return der, nil
 }
 
-func getIssuer(cert *x509.Certificate) (*x509.Certificate, *errors.Errors) {
-	var e = errors.New(nil)
-	var issuer *x509.Certificate
-	for _, url := range cert.IssuingCertificateURL {
-		// download if not in cache
-		var err error
-		issuer, err = downloadCert(url)
-		if err != nil {
-			e.Err("Failed to download issuer certificate from '%s': %s", url, err.Error())
+// decodeCertificate returns the DER bytes of a PEM or DER encoded certificate,
+// the end-entity certificate is returned for a PKCS#7 bundle.
+func decodeCertificate(b []byte) ([]byte, error) {
+	// decode pem
+	if block, _ := pem.Decode(b); block != nil {
+		if block.Type == "PKCS7" || block.Type == "CMS" {
+			return pkcs7EndEntity(block.Bytes)
 		}
-		if issuer != nil {
-			break
+		if !strings.HasSuffix(block.Type, "CERTIFICATE") {
+			return nil, fmt.Errorf("PEM block of type %s is not a certificate", block.Type)
 		}
+		return block.Bytes, nil
 	}
 
-	// check if the signature on this certificate can be verified with the downloaded issuer certificate
-	if issuer != nil {
-		err := cert.CheckSignatureFrom(issuer)
-		if err != nil {
-			e.Err("Signature not from downloaded issuer: %s", err.Error())
-		}
+	if isPKCS7(b) {
+		return pkcs7EndEntity(b)
 	}
 
-	return issuer, e
+	// A DER encoded certificate always starts with a SEQUENCE
+	if len(b) == 0 || b[0] != 0x30 {
+		return nil, fmt.Errorf("no PEM or DER encoded certificate found")
+	}
+	return b, nil
 }
 
-func downloadCert(url string) (*x509.Certificate, error) {
-	// download file
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
+// loadCTLogList loads a CT log list from a file or downloads it from a url
+func loadCTLogList(src string) (*ctlogs.LogList, error) {
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		return ctlogs.Load(src)
 	}
-
-	if resp.StatusCode > 399 {
-		return nil, fmt.Errorf("Unexpected response '%s'", resp.Status)
+	if offline {
+		return nil, fmt.Errorf("can't download the CT log list '%s' in offline mode", src)
 	}
 
-	// read response body
-	derBytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return ctlogs.Fetch(ctx, src)
+}
+
+// verifyRevoked checks if the certificate is revoked using its OCSP servers or
+// CRLs, ok is false when the revocation status could not be determined within
+// lintTimeout.
+func verifyRevoked(cert *x509.Certificate) (revoked, ok bool) {
+	ctx, cancel := lintContext()
+	defer cancel()
+
+	type status struct{ revoked, ok bool }
+	done := make(chan status, 1)
+	go func() {
+		revoked, ok := revoke.VerifyCertificate(cert)
+		done <- status{revoked, ok}
+	}()
+
+	select {
+	case s := <-done:
+		return s.revoked, s.ok
+	case <-ctx.Done():
+		return false, false
 	}
-	resp.Body.Close()
+}
+
+// issuerFetcher fetches the issuers of certificates that are not found in the
+// cache, a bundle or the issuer file
+var issuerFetcher certdata.IssuerFetcher = &certdata.HTTPFetcher{
+	Observe: func(url string, d time.Duration, err error) {
+		countAIAFetch(d, err)
+	},
+}
+
+// getIssuerPool builds all paths of cert from the fetched issuers, it returns
+// the issuer of cert, a pool with all fetched issuers and the paths. The
+// issuer is taken from the shortest complete path, cross-signed intermediates
+// of the other paths are in the pool to verify the chain.
+func getIssuerPool(ctx context.Context, cert *x509.Certificate) (*x509.Certificate, *x509.CertPool, [][]*x509.Certificate, *errors.Errors) {
+	var e = errors.New(nil)
+	var issuer *x509.Certificate
 
-	// decode pem, if pem
-	block, _ := pem.Decode(derBytes)
-	if block != nil {
-		derBytes = block.Bytes
+	paths, err := certdata.BuildPaths(ctx, issuerFetcher, cert)
+	if errs, ok := err.(certdata.ChainError); ok {
+		for _, err := range errs {
+			switch err := err.(type) {
+			case *certdata.FetchError:
+				e.Err("Failed to download issuer certificate from '%s': %s", err.URL, err.Err.Error())
+			case *certdata.SignatureError:
+				e.Err("Signature not from downloaded issuer: %s", err.Err.Error())
+			default:
+				e.Err("Failed to fetch issuer certificate: %s", err.Error())
+			}
+		}
 	}
 
-	issuer, err := x509.ParseCertificate(derBytes)
-	if err != nil {
-		return nil, err
+	pool := x509.NewCertPool()
+	for _, path := range paths {
+		for _, ic := range path {
+			pool.AddCert(ic)
+		}
+	}
+	if len(paths) > 0 {
+		issuer = paths[0][0]
 	}
 
-	return issuer, nil
+	return issuer, pool, paths, e
 }
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/weyhmueller/certlint/errors"
+
+	"golang.org/x/net/websocket"
+)
+
+// certStreamReconnect is the delay before reconnecting when the connection
+// to a CertStream feed is lost
+var certStreamReconnect = 5 * time.Second
+
+// errCertStreamNoDER indicates a feed that does not include the certificates,
+// for example the default CertStream feed instead of the full stream.
+var errCertStreamNoDER = fmt.Errorf("CertStream feed does not include the certificates, use a full stream feed")
+
+// certStreamMessage is a message of a CertStream feed, only the fields we use
+// are decoded.
+type certStreamMessage struct {
+	MessageType string `json:"message_type"`
+	Data        struct {
+		UpdateType string `json:"update_type"`
+		LeafCert   struct {
+			AsDER []byte `json:"as_der"`
+		} `json:"leaf_cert"`
+		CertIndex int64 `json:"cert_index"`
+		Source    struct {
+			URL string `json:"url"`
+		} `json:"source"`
+	} `json:"data"`
+}
+
+// doCertStream queues the certificates and precertificates of a CertStream
+// feed as they are logged, until stop is closed. The log and index of the
+// entry are included in the results.
+func doCertStream(uri string, stop <-chan struct{}) {
+	defer close(jobs)
+
+feed:
+	for {
+		err := readCertStream(uri, stop)
+		if err == nil {
+			break
+		}
+		fmt.Fprintln(os.Stderr, err)
+		if err == errCertStreamNoDER {
+			break
+		}
+
+		// Reconnect after a delay, unless we are stopped
+		select {
+		case <-stop:
+			break feed
+		case <-time.After(certStreamReconnect):
+		}
+	}
+
+	fmt.Printf("Checked %d certificates\n", count)
+}
+
+// readCertStream queues the certificates of a CertStream feed until the
+// connection fails, it returns nil when stop is closed.
+func readCertStream(uri string, stop <-chan struct{}) error {
+	ws, err := websocket.Dial(uri, "", "http://localhost/")
+	if err != nil {
+		return err
+	}
+
+	// Closing the connection interrupts the blocking receive
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-stop:
+		case <-done:
+		}
+		ws.Close()
+	}()
+
+	for {
+		var msg certStreamMessage
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			select {
+			case <-stop:
+				return nil
+			default:
+				return err
+			}
+		}
+
+		// Skip heartbeats and other messages
+		if msg.MessageType != "certificate_update" {
+			continue
+		}
+		if len(msg.Data.LeafCert.AsDER) == 0 {
+			return errCertStreamNoDER
+		}
+
+		source := fmt.Sprintf("%s#%d", msg.Data.Source.URL, msg.Data.CertIndex)
+		if msg.Data.UpdateType != "X509LogEntry" && msg.Data.UpdateType != "PrecertLogEntry" {
+			var e = errors.New(nil)
+			e.Err("Unknown CertStream update type %s", msg.Data.UpdateType)
+			notePriority(e)
+			results <- testResult{Source: source, Errors: e}
+			continue
+		}
+
+		count++
+		jobs <- bulkJob{der: msg.Data.LeafCert.AsDER, source: source}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+func TestDoCertStream(t *testing.T) {
+	defer func(j chan bulkJob, r chan testResult, c int64) { jobs, results, count = j, r, c }(jobs, results, count)
+	jobs = make(chan bulkJob, 100)
+	results = make(chan testResult, 100)
+
+	der := base64.StdEncoding.EncodeToString(getCertificate("./testdata/nokeyusage.pem"))
+	ts := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		websocket.Message.Send(ws, `{"message_type":"heartbeat"}`)
+		for i, update := range []string{"X509LogEntry", "PrecertLogEntry", "JSONLogEntry"} {
+			websocket.Message.Send(ws, fmt.Sprintf(`{"message_type":"certificate_update","data":{"update_type":%q,"leaf_cert":{"as_der":%q},"cert_index":%d,"source":{"url":"https://ct.example.com/"}}}`, update, der, i))
+		}
+
+		// Keep the connection open until the client closes it
+		var msg string
+		for websocket.Message.Receive(ws, &msg) == nil {
+		}
+	}))
+	defer ts.Close()
+
+	stop := make(chan struct{})
+	go doCertStream("ws"+strings.TrimPrefix(ts.URL, "http"), stop)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case job := <-jobs:
+			if source := fmt.Sprintf("https://ct.example.com/#%d", i); job.source != source {
+				t.Errorf("Expected source %s, got %s", source, job.source)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected 2 certificates, got %d", i)
+		}
+	}
+
+	select {
+	case r := <-results:
+		if r.Source != "https://ct.example.com/#2" || len(r.Errors.List()) != 1 {
+			t.Errorf("Expected an error for the unknown update type, got %v", r.Errors.List())
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Expected a result for the unknown update type")
+	}
+
+	close(stop)
+	for job := range jobs {
+		t.Errorf("Unexpected job from %s", job.source)
+	}
+}
+
+func TestDoCertStreamNoDER(t *testing.T) {
+	defer func(j chan bulkJob, c int64) { jobs, count = j, c }(jobs, count)
+	jobs = make(chan bulkJob, 100)
+
+	ts := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		websocket.Message.Send(ws, `{"message_type":"certificate_update","data":{"update_type":"X509LogEntry","leaf_cert":{},"cert_index":0}}`)
+	}))
+	defer ts.Close()
+
+	// A feed without certificates stops without reconnecting
+	doCertStream("ws"+strings.TrimPrefix(ts.URL, "http"), make(chan struct{}))
+	if _, ok := <-jobs; ok {
+		t.Error("Expected no certificates")
+	}
+}
@@ -1,6 +1,7 @@
 package checks
 
 import (
+	"context"
 	"sync"
 
 	"github.com/weyhmueller/certlint/certdata"
@@ -14,14 +15,23 @@ type certificate []certificateCheck
 type certificateCheck struct {
 	name   string
 	filter *Filter
-	f      func(*certdata.Data) *errors.Errors
+	f      func(context.Context, *certdata.Data) *errors.Errors
 }
 
 // Certificate contains all imported certificate checks
 var Certificate certificate
 
 // RegisterCertificateCheck adds a new check to Cerificates
-func RegisterCertificateCheck(name string, filter *Filter, f func(*certdata.Data) *errors.Errors) {
+func RegisterCertificateCheck(name string, meta Metadata, filter *Filter, f func(*certdata.Data) *errors.Errors) {
+	RegisterCertificateCheckContext(name, meta, filter, func(_ context.Context, d *certdata.Data) *errors.Errors {
+		return f(d)
+	})
+}
+
+// RegisterCertificateCheckContext adds a new check to Cerificates that receives
+// the context of CheckContext, for checks that need to honor cancellation.
+func RegisterCertificateCheckContext(name string, meta Metadata, filter *Filter, f func(context.Context, *certdata.Data) *errors.Errors) {
+	registerMetadata(name, meta)
 	certMutex.Lock()
 	Certificate = append(Certificate, certificateCheck{name, filter, f})
 	certMutex.Unlock()
@@ -29,14 +39,42 @@ func RegisterCertificateCheck(name string, filter *Filter, f func(*certdata.Data
 
 // Check runs all the registered certificate checks
 func (c certificate) Check(d *certdata.Data) *errors.Errors {
+	return c.CheckContext(context.Background(), d)
+}
+
+// CheckContext runs all the registered certificate checks, the remaining
+// checks are skipped when the context is done.
+func (c certificate) CheckContext(ctx context.Context, d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
 	for _, cc := range c {
+		if disabled[cc.name] {
+			continue
+		}
 		if cc.filter != nil && !cc.filter.Check(d) {
 			continue
 		}
-		e.Append(cc.f(d))
+		if ctx.Err() != nil {
+			e.Append(aborted(ctx))
+			break
+		}
+		r := cc.f(ctx, d)
+		r.SetCheck(cc.name)
+		e.Append(r)
 	}
 
 	return e
 }
+
+// Names returns the names of all registered certificate checks that are not
+// disabled by Select
+func (c certificate) Names() []string {
+	var names []string
+	for _, cc := range c {
+		if disabled[cc.name] {
+			continue
+		}
+		names = append(names, cc.name)
+	}
+	return names
+}
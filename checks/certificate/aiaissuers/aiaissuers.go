@@ -1,6 +1,7 @@
 package aiaissuers
 
 import (
+	"bytes"
 	"net/url"
 
 	"github.com/weyhmueller/certlint/certdata"
@@ -11,14 +12,32 @@ import (
 const checkName = "Authority Info Access Issuers Check"
 
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/aiaissuers",
+		Description: "Certificate contains valid Authority Info Access issuer URLs",
+		Citation:    "RFC 5280 §4.2.2.1, BR §7.1.2.3",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Intermediate CA certificate contains no Authority Info Access Issuers":                 "CERTLINT_AIA_001",
+		"Certificate contains no Authority Info Access Issuers":                                 "CERTLINT_AIA_002",
+		"Certificate contains an invalid Authority Info Access Issuer URL (%s)":                 "CERTLINT_AIA_003",
+		"Certificate contains a Authority Info Access Issuer with an non-preferred scheme (%s)": "CERTLINT_AIA_004",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 	if len(d.Cert.IssuingCertificateURL) == 0 {
-		e.Err("Certificate contains no Authority Info Access Issuers")
+		switch {
+		case d.Cert.IsCA && bytes.Equal(d.Cert.RawSubject, d.Cert.RawIssuer):
+			// Root certificates have no issuer to refer to
+		case d.Cert.IsCA:
+			// Without caIssuers clients can't build the path to the root
+			e.Warning("Intermediate CA certificate contains no Authority Info Access Issuers")
+		default:
+			e.Err("Certificate contains no Authority Info Access Issuers")
+		}
 		return e
 	}
 
@@ -9,7 +9,14 @@ import (
 const checkName = "Basic Constraints Check"
 
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/basicconstraints",
+		Description: "End entity certificates are not marked as CA",
+		Citation:    "RFC 5280 §4.2.1.9, BR §7.1.2.3",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate has set CA true": "CERTLINT_BC_001",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
@@ -0,0 +1,145 @@
+package caa
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "CAA Records Check"
+
+// Enabled enables the DNS queries of the check, it is disabled by default as
+// it requires network access and reflects the current records instead of the
+// records at the time of issuance.
+var Enabled bool
+
+// Resolver is the DNS server (host:port) that is queried, the first name
+// server of /etc/resolv.conf when empty
+var Resolver string
+
+func init() {
+	filter := &checks.Filter{
+		Type: []string{"DV", "OV", "EV"},
+	}
+	checks.RegisterCertificateCheckContext(checkName, checks.Metadata{
+		ID:          "certificate/caa",
+		Description: "CAA records of the dNSName SANs allow issuance of the certificate",
+		Citation:    "RFC 8659 §3, BR §3.2.2.8",
+	}, filter, Check)
+	errors.RegisterCodes(map[string]string{
+		"CAA records of %s at %s allow issuance by %s":                  "CERTLINT_CAA_001",
+		"CAA records of %s at %s forbid issuance":                       "CERTLINT_CAA_002",
+		"CAA records of %s at %s contain the unknown critical tag '%s'": "CERTLINT_CAA_003",
+		"CAA lookup of %s failed: %s":                                   "CERTLINT_CAA_004",
+	})
+}
+
+// Check queries the CAA records of all dNSName SANs and reports the issuers
+// that are allowed to issue certificates for them
+//
+// https://tools.ietf.org/html/rfc8659#section-3
+func Check(ctx context.Context, d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+	if !Enabled {
+		return e
+	}
+
+	resolver := Resolver
+	if len(resolver) == 0 {
+		resolver = systemResolver()
+	}
+
+	// Names share their parent domains, query each name once
+	cache := make(map[string][]record)
+
+	for _, name := range d.Cert.DNSNames {
+		domain := strings.ToLower(strings.TrimSuffix(name, "."))
+		wildcard := strings.HasPrefix(domain, "*.")
+		domain = strings.TrimPrefix(domain, "*.")
+
+		at, records, err := relevantRecords(ctx, resolver, domain, cache)
+		if err != nil {
+			e.Notice("CAA lookup of %s failed: %s", name, err.Error())
+			continue
+		}
+		if len(records) == 0 {
+			continue
+		}
+
+		issuers, forbidden, unknown := evaluate(records, wildcard)
+		switch {
+		case len(unknown) > 0:
+			e.Warning("CAA records of %s at %s contain the unknown critical tag '%s'", name, at, unknown)
+		case forbidden:
+			e.Warning("CAA records of %s at %s forbid issuance", name, at)
+		case len(issuers) > 0:
+			e.Info("CAA records of %s at %s allow issuance by %s", name, at, strings.Join(issuers, ", "))
+		}
+	}
+
+	return e
+}
+
+// relevantRecords climbs the domain tree from domain up to the top-level
+// domain and returns the first non-empty CAA record set, RFC 8659 §3
+func relevantRecords(ctx context.Context, resolver, domain string, cache map[string][]record) (string, []record, error) {
+	labels := strings.Split(domain, ".")
+	for i := range labels {
+		name := strings.Join(labels[i:], ".")
+		records, ok := cache[name]
+		if !ok {
+			var err error
+			if records, err = lookup(ctx, resolver, name); err != nil {
+				return name, nil, err
+			}
+			cache[name] = records
+		}
+		if len(records) > 0 {
+			return name, records, nil
+		}
+	}
+	return "", nil, nil
+}
+
+// evaluate returns the issuer domains of the relevant issue or issuewild
+// records. Issuance is forbidden when the records only contain empty issuer
+// domains, or a critical tag that is not understood.
+func evaluate(records []record, wildcard bool) (issuers []string, forbidden bool, unknown string) {
+	// The issuewild records take precedence for wildcard names, RFC 8659 §4.3
+	tag := "issue"
+	if wildcard {
+		for _, r := range records {
+			if r.Tag == "issuewild" {
+				tag = "issuewild"
+			}
+		}
+	}
+
+	var relevant bool
+	for _, r := range records {
+		switch r.Tag {
+		case "issue", "issuewild", "iodef", "issuemail", "issuevmc":
+		default:
+			if r.Critical() {
+				return nil, true, r.Tag
+			}
+		}
+		if r.Tag != tag {
+			continue
+		}
+		relevant = true
+
+		// The issuer domain is followed by optional parameters
+		issuer := strings.TrimSpace(strings.SplitN(r.Value, ";", 2)[0])
+		if len(issuer) > 0 {
+			issuers = append(issuers, issuer)
+		}
+	}
+
+	sort.Strings(issuers)
+	return issuers, relevant && len(issuers) == 0, ""
+}
@@ -0,0 +1,169 @@
+package caa
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// typeCAA is the CAA resource record type, RFC 8659 §4.1
+const typeCAA = dnsmessage.Type(257)
+
+// flagCritical is the issuer critical flag of a CAA record
+const flagCritical = 128
+
+// record is a CAA resource record
+type record struct {
+	Flags uint8
+	Tag   string
+	Value string
+}
+
+// Critical returns true if the issuer critical flag is set
+func (r record) Critical() bool {
+	return r.Flags&flagCritical != 0
+}
+
+// systemResolver returns the first name server of /etc/resolv.conf
+func systemResolver() string {
+	b, err := ioutil.ReadFile("/etc/resolv.conf")
+	if err == nil {
+		for _, line := range strings.Split(string(b), "\n") {
+			f := strings.Fields(line)
+			if len(f) >= 2 && f[0] == "nameserver" {
+				return net.JoinHostPort(f[1], "53")
+			}
+		}
+	}
+	return "127.0.0.1:53"
+}
+
+// lookup returns the CAA records of name, a name that does not exist has no
+// records.
+func lookup(ctx context.Context, resolver, name string) ([]record, error) {
+	n, err := dnsmessage.NewName(strings.TrimSuffix(name, ".") + ".")
+	if err != nil {
+		return nil, err
+	}
+
+	id := uint16(rand.Intn(1 << 16))
+	query, err := (&dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: id, RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: n, Type: typeCAA, Class: dnsmessage.ClassINET}},
+	}).Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := exchange(ctx, "udp", resolver, query)
+	if err != nil {
+		return nil, err
+	}
+	var m dnsmessage.Message
+	if err := m.Unpack(resp); err != nil {
+		return nil, err
+	}
+
+	// Retry a truncated response over TCP
+	if m.Truncated {
+		if resp, err = exchange(ctx, "tcp", resolver, query); err != nil {
+			return nil, err
+		}
+		if err := m.Unpack(resp); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.ID != id {
+		return nil, fmt.Errorf("unexpected DNS response ID")
+	}
+	switch m.RCode {
+	case dnsmessage.RCodeSuccess:
+	case dnsmessage.RCodeNameError:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("DNS response %s", m.RCode)
+	}
+
+	var records []record
+	for _, a := range m.Answers {
+		if a.Header.Type != typeCAA {
+			continue
+		}
+		u, ok := a.Body.(*dnsmessage.UnknownResource)
+		if !ok {
+			continue
+		}
+		r, err := parseRecord(u.Data)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// parseRecord parses the data of a CAA resource record
+func parseRecord(b []byte) (record, error) {
+	if len(b) < 2 || len(b) < 2+int(b[1]) {
+		return record{}, fmt.Errorf("invalid CAA record")
+	}
+	return record{
+		Flags: b[0],
+		Tag:   strings.ToLower(string(b[2 : 2+b[1]])),
+		Value: string(b[2+b[1]:]),
+	}, nil
+}
+
+// exchange sends a DNS query over udp or tcp and returns the response
+func exchange(ctx context.Context, network, resolver string, query []byte) ([]byte, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, resolver)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	if dl, ok := ctx.Deadline(); ok && dl.Before(deadline) {
+		deadline = dl
+	}
+	conn.SetDeadline(deadline)
+
+	if network == "udp" {
+		if _, err := conn.Write(query); err != nil {
+			return nil, err
+		}
+		b := make([]byte, 4096)
+		n, err := conn.Read(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+
+	// DNS over TCP prefixes messages with their length
+	msg := make([]byte, 2+len(query))
+	binary.BigEndian.PutUint16(msg, uint16(len(query)))
+	copy(msg[2:], query)
+	if _, err := conn.Write(msg); err != nil {
+		return nil, err
+	}
+	l := make([]byte, 2)
+	if _, err := io.ReadFull(conn, l); err != nil {
+		return nil, err
+	}
+	b := make([]byte, binary.BigEndian.Uint16(l))
+	if _, err := io.ReadFull(conn, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,52 @@
+package ct
+
+import (
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/ctlogs"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "Certificate Transparency Check"
+
+func init() {
+	filter := &checks.Filter{
+		Type: []string{"DV", "OV", "EV"},
+		// Precertificates can't contain SCTs
+		SkipPrecert: true,
+	}
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/ct",
+		Description: "Certificate contains an embedded SCT list",
+		Citation:    "RFC 6962 §3.3",
+	}, filter, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate contains no embedded SCT list": "CERTLINT_CT_001",
+	})
+}
+
+// Check verifies if a final certificate contains embedded SCTs
+//
+// https://tools.ietf.org/html/rfc6962#section-3.3
+//
+func Check(d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	if d.Cert.IsCA {
+		return e
+	}
+
+	var hasSCTList bool
+	for _, ext := range d.Cert.Extensions {
+		if ext.Id.Equal(ctlogs.SCTListOID) {
+			hasSCTList = true
+		}
+	}
+
+	// SCTs can also be delivered via a TLS extension or OCSP stapling
+	if !hasSCTList {
+		e.Warning("Certificate contains no embedded SCT list")
+	}
+
+	return e
+}
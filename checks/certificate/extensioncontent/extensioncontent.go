@@ -0,0 +1,64 @@
+package extensioncontent
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "Extension Content Check"
+
+// maxExtensionSize is the largest extension value we consider sane, anything
+// above is most likely smuggled data or a generation bug.
+const maxExtensionSize = 64 * 1024
+
+func init() {
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/extensioncontent",
+		Description: "Extensions are not oversized and contain no key material",
+		Citation:    "RFC 5280 §4.2",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate contains an oversized extension (%s) of %d bytes": "CERTLINT_EXTC_001",
+		"Certificate extension (%s) contains PEM encoded key material": "CERTLINT_EXTC_002",
+	})
+}
+
+// Check verifies that the certificate extensions do not carry oversized values
+// or key material that should never be part of a certificate.
+func Check(d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	for _, ext := range d.Cert.Extensions {
+		if len(ext.Value) > maxExtensionSize {
+			e.Warning("Certificate contains an oversized extension (%s) of %d bytes", ext.Id.String(), len(ext.Value))
+		}
+
+		// Only the RFC 5280 id-ce and id-pe arcs are considered standard
+		id := ext.Id.String()
+		if strings.HasPrefix(id, "2.5.29.") || strings.HasPrefix(id, "1.3.6.1.5.5.7.1.") {
+			continue
+		}
+		if containsPEMKey(ext.Value) {
+			e.Err("Certificate extension (%s) contains PEM encoded key material", id)
+		}
+	}
+
+	return e
+}
+
+// containsPEMKey returns true if a PEM header of a (private) key is found
+func containsPEMKey(b []byte) bool {
+	i := bytes.Index(b, []byte("-----BEGIN "))
+	if i < 0 {
+		return false
+	}
+	header := b[i:]
+	if j := bytes.IndexByte(header, '\n'); j > 0 {
+		header = header[:j]
+	}
+	return bytes.Contains(header, []byte("KEY-----"))
+}
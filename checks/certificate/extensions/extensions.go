@@ -1,6 +1,8 @@
 package extensions
 
 import (
+	"context"
+
 	"github.com/weyhmueller/certlint/certdata"
 	"github.com/weyhmueller/certlint/checks"
 	"github.com/weyhmueller/certlint/errors"
@@ -9,15 +11,15 @@ import (
 const checkName = "Extensions Check"
 
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	checks.RegisterCertificateCheckContext(checkName, checks.Metadata{
+		ID:          "certificate/extensions",
+		Description: "Runs the extension checks for all extensions of the certificate",
+		Citation:    "RFC 5280 §4.2",
+	}, nil, Check)
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
-func Check(d *certdata.Data) *errors.Errors {
-	var e = errors.New(nil)
-	for _, ext := range d.Cert.Extensions {
-		// Check for any imported extensions and run all matching
-		e.Append(checks.Extensions.Check(ext, d))
-	}
-	return e
+func Check(ctx context.Context, d *certdata.Data) *errors.Errors {
+	// Check for any imported extensions and run all matching
+	return checks.Extensions.CheckAllContext(ctx, d.Cert.Extensions, d)
 }
@@ -11,7 +11,19 @@ import (
 const checkName = "Extended Key Usage Check"
 
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/extkeyusage",
+		Description: "Extended key usages are allowed for the certificate type",
+		Citation:    "RFC 5280 §4.2.1.12, BR §7.1.2.3",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate contains the EmailProtection extended key usage, which is not allowed for %s certificates": "CERTLINT_EKU_001",
+		"Certificate contains the TimeStamping extended key usage, which is not allowed for %s certificates":    "CERTLINT_EKU_002",
+		"Certificate contains the OCSPSigning extended key usage, which is not allowed for %s certificates":     "CERTLINT_EKU_003",
+		"Certificate contains the CodeSigning extended key usage, which is not allowed for %s certificates":     "CERTLINT_EKU_004",
+		"Certificate contains a key usage different from ServerAuth, ClientAuth or ServerGatedCrypto":           "CERTLINT_EKU_005",
+		"Certificate contains a key usage different from ClientAuth or EmailProtection":                         "CERTLINT_EKU_006",
+	})
 }
 
 // Check verifies if the the required/allowed extended keyusages
@@ -25,9 +37,19 @@ func Check(d *certdata.Data) *errors.Errors {
 	for _, ku := range d.Cert.ExtKeyUsage {
 		switch d.Type {
 		case "DV", "OV", "EV":
-			if ku != x509.ExtKeyUsageServerAuth && ku != x509.ExtKeyUsageClientAuth && ku != x509.ExtKeyUsageMicrosoftServerGatedCrypto {
+			// Report all cross purpose key usages
+			switch ku {
+			case x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageMicrosoftServerGatedCrypto:
+			case x509.ExtKeyUsageEmailProtection:
+				e.Err("Certificate contains the EmailProtection extended key usage, which is not allowed for %s certificates", d.Type)
+			case x509.ExtKeyUsageTimeStamping:
+				e.Err("Certificate contains the TimeStamping extended key usage, which is not allowed for %s certificates", d.Type)
+			case x509.ExtKeyUsageOCSPSigning:
+				e.Err("Certificate contains the OCSPSigning extended key usage, which is not allowed for %s certificates", d.Type)
+			case x509.ExtKeyUsageCodeSigning:
+				e.Err("Certificate contains the CodeSigning extended key usage, which is not allowed for %s certificates", d.Type)
+			default:
 				e.Err("Certificate contains a key usage different from ServerAuth, ClientAuth or ServerGatedCrypto")
-				return e
 			}
 		case "PS":
 			if ku != x509.ExtKeyUsageClientAuth && ku != x509.ExtKeyUsageEmailProtection {
@@ -0,0 +1,169 @@
+package idn
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "Internationalized Domain Names Check"
+
+// acePrefix is the ACE prefix of IDNA A-labels, RFC 5890 §2.3.2.5
+const acePrefix = "xn--"
+
+// allowedScriptSets are the combinations of scripts that are commonly used
+// together, the Highly Restrictive level of Unicode TR 39 §5.2
+var allowedScriptSets = [][]string{
+	{"Latin", "Han", "Hiragana", "Katakana"},
+	{"Latin", "Han", "Bopomofo"},
+	{"Latin", "Han", "Hangul"},
+}
+
+func init() {
+	filter := &checks.Filter{
+		Type: []string{"DV", "OV", "IV", "EV"},
+	}
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/idn",
+		Description: "A-labels of dNSNames are valid Punycode encoded IDNA2008 labels of a single script",
+		Citation:    "RFC 3492, RFC 5890 §2.3.2.1, RFC 5891 §5.4, Unicode TR 39 §5.2",
+	}, filter, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate subjectAltName '%s' contains the A-label '%s' with invalid Punycode: %s":        "CERTLINT_IDN_001",
+		"Certificate subjectAltName '%s' contains the A-label '%s' that is not in canonical form":    "CERTLINT_IDN_002",
+		"Certificate subjectAltName '%s' contains the A-label '%s' of an ASCII label":                "CERTLINT_IDN_003",
+		"Certificate subjectAltName '%s' contains the A-label '%s' with the disallowed character %U": "CERTLINT_IDN_004",
+		"Certificate subjectAltName '%s' contains the A-label '%s' with invalid hyphens":             "CERTLINT_IDN_005",
+		"Certificate subjectAltName '%s' contains the A-label '%s' starting with a combining mark":   "CERTLINT_IDN_006",
+		"Certificate subjectAltName '%s' contains the label '%s' mixing the scripts %s":              "CERTLINT_IDN_007",
+		"Certificate subjectAltName '%s' contains the reserved label '%s'":                           "CERTLINT_IDN_008",
+	})
+}
+
+// Check validates the A-labels of all dNSNames, raw non-ASCII characters are
+// already rejected when parsing the subjectAltName.
+//
+// https://tools.ietf.org/html/rfc5891#section-5.4
+func Check(d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	for _, n := range d.Cert.DNSNames {
+		for _, label := range strings.Split(strings.TrimSuffix(n, "."), ".") {
+			l := strings.ToLower(label)
+
+			// Labels with hyphens in the third and fourth position are
+			// reserved for ACE prefixes, only xn-- is defined
+			if len(l) >= 4 && l[2:4] == "--" && !strings.HasPrefix(l, acePrefix) {
+				e.Err("Certificate subjectAltName '%s' contains the reserved label '%s'", n, label)
+				continue
+			}
+			if strings.HasPrefix(l, acePrefix) {
+				checkALabel(e, n, label)
+			}
+		}
+	}
+
+	return e
+}
+
+// checkALabel decodes an A-label and verifies the resulting U-label
+func checkALabel(e *errors.Errors, name, label string) {
+	u, err := decodePunycode(strings.ToLower(label[len(acePrefix):]))
+	if err != nil {
+		e.Err("Certificate subjectAltName '%s' contains the A-label '%s' with invalid Punycode: %s", name, label, err.Error())
+		return
+	}
+
+	var ascii = true
+	for _, r := range u {
+		if r >= 0x80 {
+			ascii = false
+		}
+	}
+	if ascii {
+		e.Err("Certificate subjectAltName '%s' contains the A-label '%s' of an ASCII label", name, label)
+		return
+	}
+
+	// The A-label must be the encoding of its U-label, RFC 5891 §5.4
+	if acePrefix+encodePunycode(u) != strings.ToLower(label) {
+		e.Err("Certificate subjectAltName '%s' contains the A-label '%s' that is not in canonical form", name, label)
+	}
+
+	checkULabel(e, name, label, u)
+}
+
+// checkULabel verifies the characters of a U-label. Without the IDNA2008
+// derived property tables this approximates PVALID with lowercase letters,
+// marks and digits, RFC 5892 §2.
+func checkULabel(e *errors.Errors, name, label string, u []rune) {
+	if u[0] == '-' || u[len(u)-1] == '-' || (len(u) >= 4 && u[2] == '-' && u[3] == '-') {
+		e.Err("Certificate subjectAltName '%s' contains the A-label '%s' with invalid hyphens", name, label)
+	}
+	if unicode.Is(unicode.M, u[0]) {
+		e.Err("Certificate subjectAltName '%s' contains the A-label '%s' starting with a combining mark", name, label)
+	}
+
+	scripts := make(map[string]bool)
+	for _, r := range u {
+		switch {
+		case r == '-':
+		case unicode.IsUpper(r) || unicode.IsTitle(r):
+			e.Err("Certificate subjectAltName '%s' contains the A-label '%s' with the disallowed character %U", name, label, r)
+		case unicode.IsLetter(r), unicode.Is(unicode.M, r), unicode.IsDigit(r):
+		default:
+			e.Err("Certificate subjectAltName '%s' contains the A-label '%s' with the disallowed character %U", name, label, r)
+		}
+
+		if s := script(r); len(s) > 0 {
+			scripts[s] = true
+		}
+	}
+
+	if !allowedScripts(scripts) {
+		var list []string
+		for s := range scripts {
+			list = append(list, s)
+		}
+		sort.Strings(list)
+		e.Warning("Certificate subjectAltName '%s' contains the label '%s' mixing the scripts %s", name, label, strings.Join(list, ", "))
+	}
+}
+
+// script returns the Unicode script of r, characters of the Common and
+// Inherited scripts are used with any script and are ignored
+func script(r rune) string {
+	for name, table := range unicode.Scripts {
+		if name == "Common" || name == "Inherited" {
+			continue
+		}
+		if unicode.Is(table, r) {
+			return name
+		}
+	}
+	return ""
+}
+
+// allowedScripts returns true if the scripts are a single script or one of the
+// allowed combinations
+func allowedScripts(scripts map[string]bool) bool {
+	if len(scripts) <= 1 {
+		return true
+	}
+	for _, set := range allowedScriptSets {
+		var n int
+		for _, s := range set {
+			if scripts[s] {
+				n++
+			}
+		}
+		if n == len(scripts) {
+			return true
+		}
+	}
+	return false
+}
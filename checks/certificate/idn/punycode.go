@@ -0,0 +1,179 @@
+package idn
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Bootstring parameters of Punycode, RFC 3492 §5
+const (
+	base        = 36
+	tMin        = 1
+	tMax        = 26
+	skew        = 38
+	damp        = 700
+	initialBias = 72
+	initialN    = 128
+)
+
+// decodePunycode decodes the Punycode of an A-label without the xn-- prefix,
+// RFC 3492 §6.2
+func decodePunycode(s string) ([]rune, error) {
+	var output []rune
+	pos := strings.LastIndex(s, "-")
+	if pos >= 0 {
+		for _, c := range s[:pos] {
+			if c >= 0x80 {
+				return nil, fmt.Errorf("non-basic code point in basic string")
+			}
+			output = append(output, c)
+		}
+		s = s[pos+1:]
+	}
+
+	i, n, bias := 0, initialN, initialBias
+	for len(s) > 0 {
+		oldi, w := i, 1
+		for k := base; ; k += base {
+			if len(s) == 0 {
+				return nil, fmt.Errorf("truncated input")
+			}
+			digit, ok := decodeDigit(s[0])
+			if !ok {
+				return nil, fmt.Errorf("invalid character '%c'", s[0])
+			}
+			s = s[1:]
+
+			if digit > (math.MaxInt32-i)/w {
+				return nil, fmt.Errorf("overflow")
+			}
+			i += digit * w
+
+			t := threshold(k, bias)
+			if digit < t {
+				break
+			}
+			if w > math.MaxInt32/(base-t) {
+				return nil, fmt.Errorf("overflow")
+			}
+			w *= base - t
+		}
+
+		l := len(output) + 1
+		bias = adapt(i-oldi, l, oldi == 0)
+		if i/l > math.MaxInt32-n {
+			return nil, fmt.Errorf("overflow")
+		}
+		n += i / l
+		i %= l
+		if n < initialN || n > 0x10FFFF {
+			return nil, fmt.Errorf("invalid code point %d", n)
+		}
+
+		output = append(output, 0)
+		copy(output[i+1:], output[i:])
+		output[i] = rune(n)
+		i++
+	}
+	return output, nil
+}
+
+// encodePunycode encodes a U-label as Punycode without the xn-- prefix,
+// RFC 3492 §6.3
+func encodePunycode(label []rune) string {
+	var out []byte
+	for _, c := range label {
+		if c < 0x80 {
+			out = append(out, byte(c))
+		}
+	}
+	b := len(out)
+	h := b
+	if b > 0 {
+		out = append(out, '-')
+	}
+
+	n, delta, bias := initialN, 0, initialBias
+	for h < len(label) {
+		m := math.MaxInt32
+		for _, c := range label {
+			if int(c) >= n && int(c) < m {
+				m = int(c)
+			}
+		}
+		delta += (m - n) * (h + 1)
+		n = m
+
+		for _, c := range label {
+			if int(c) < n {
+				delta++
+			}
+			if int(c) != n {
+				continue
+			}
+			q := delta
+			for k := base; ; k += base {
+				t := threshold(k, bias)
+				if q < t {
+					break
+				}
+				out = append(out, encodeDigit(t+(q-t)%(base-t)))
+				q = (q - t) / (base - t)
+			}
+			out = append(out, encodeDigit(q))
+			bias = adapt(delta, h+1, h == b)
+			delta = 0
+			h++
+		}
+		delta++
+		n++
+	}
+	return string(out)
+}
+
+func threshold(k, bias int) int {
+	switch {
+	case k <= bias:
+		return tMin
+	case k >= bias+tMax:
+		return tMax
+	}
+	return k - bias
+}
+
+// adapt is the bias adaptation function of RFC 3492 §6.1
+func adapt(delta, numPoints int, first bool) int {
+	if first {
+		delta /= damp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+
+	k := 0
+	for delta > ((base-tMin)*tMax)/2 {
+		delta /= base - tMin
+		k += base
+	}
+	return k + (base-tMin+1)*delta/(delta+skew)
+}
+
+func decodeDigit(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c-'0') + 26, true
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a'), true
+	case c >= 'A' && c <= 'Z':
+		return int(c - 'A'), true
+	}
+	return 0, false
+}
+
+func encodeDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
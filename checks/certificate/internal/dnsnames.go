@@ -7,6 +7,23 @@ import (
 	psl "golang.org/x/net/publicsuffix"
 )
 
+// specialUseSuffixes are reserved for local or private use and can't be
+// validated, although their top-level domain is on the public suffix list
+// (RFC 8375) or they are registered as special-use domain names (RFC 6761,
+// RFC 6762 and ICANN's reservation of .internal).
+var specialUseSuffixes = []string{
+	"home.arpa",
+	"in-addr.arpa",
+	"ip6.arpa",
+	"local",
+	"localhost",
+	"internal",
+	"test",
+	"example",
+	"invalid",
+	"alt",
+}
+
 // All official domain suffixes are registered by icann, but because some
 // subdomains are not only check against the last part of the fqdn.
 func checkInternalName(fqdn string) bool {
@@ -14,10 +31,45 @@ func checkInternalName(fqdn string) bool {
 		return checkInternalIP(ip)
 	}
 
-	suffix := strings.Split(strings.ToLower(fqdn), ".")
+	name := strings.TrimSuffix(strings.ToLower(fqdn), ".")
+	name = strings.TrimPrefix(name, "*.")
+
+	// Single-label hostnames only resolve in the local network
+	if !strings.Contains(name, ".") {
+		return true
+	}
+
+	for _, s := range specialUseSuffixes {
+		if name == s || strings.HasSuffix(name, "."+s) {
+			return true
+		}
+	}
+
+	suffix := strings.Split(name, ".")
 	_, icann := psl.PublicSuffix(suffix[len(suffix)-1])
 	if icann {
 		return false
 	}
 	return true
 }
+
+// isHostname returns true if name is syntactically a host name, a wildcard or
+// trailing dot is allowed.
+func isHostname(name string) bool {
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "*."), ".")
+	if len(name) == 0 || len(name) > 253 {
+		return false
+	}
+
+	for _, l := range strings.Split(name, ".") {
+		if len(l) == 0 || len(l) > 63 || l[0] == '-' || l[len(l)-1] == '-' {
+			return false
+		}
+		for _, c := range l {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"net"
+
 	"github.com/weyhmueller/certlint/certdata"
 	"github.com/weyhmueller/certlint/checks"
 	"github.com/weyhmueller/certlint/errors"
@@ -12,7 +14,19 @@ func init() {
 	filter := &checks.Filter{
 		Type: []string{"DV", "OV", "IV", "EV"},
 	}
-	checks.RegisterCertificateCheck(checkName, filter, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/internal",
+		Description: "Names are no internal server names or private IP addresses",
+		Citation:    "BR §7.1.4.2.1",
+	}, filter, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate contains an internal server name in the common name '%s'":     "CERTLINT_INT_001",
+		"Certificate subjectAltName '%s' contains an internal server name":         "CERTLINT_INT_002",
+		"Certificate subjectAltName '%v' contains a non global unicast IP address": "CERTLINT_INT_003",
+		"Certificate subjectAltName '%v' contains a private or local IP address":   "CERTLINT_INT_004",
+		"Certificate subjectAltName '%v' contains a reserved IP address":           "CERTLINT_INT_005",
+		"Certificate subjectAltName dNSName '%s' contains an IP address":           "CERTLINT_INT_006",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
@@ -20,10 +34,16 @@ func init() {
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
-	if checkInternalName(d.Cert.Subject.CommonName) {
-		e.Err("Certificate contains an internal server name in the common name '%s'", d.Cert.Subject.CommonName)
+	// Only a common name that contains a host name or IP address can name an
+	// internal server
+	if cn := d.Cert.Subject.CommonName; (net.ParseIP(cn) != nil || isHostname(cn)) && checkInternalName(cn) {
+		e.Err("Certificate contains an internal server name in the common name '%s'", cn)
 	}
 	for _, n := range d.Cert.DNSNames {
+		// IP addresses must be in an iPAddress entry, BR §7.1.4.2.1
+		if net.ParseIP(n) != nil {
+			e.Err("Certificate subjectAltName dNSName '%s' contains an IP address", n)
+		}
 		if checkInternalName(n) {
 			e.Err("Certificate subjectAltName '%s' contains an internal server name", n)
 		}
@@ -36,6 +56,8 @@ func Check(d *certdata.Data) *errors.Errors {
 		}
 		if checkInternalIP(ip) {
 			e.Err("Certificate subjectAltName '%v' contains a private or local IP address", ip)
+		} else if checkReservedIP(ip) {
+			e.Err("Certificate subjectAltName '%v' contains a reserved IP address", ip)
 		}
 	}
 
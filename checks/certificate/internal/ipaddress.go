@@ -2,29 +2,59 @@ package internal
 
 import "net"
 
+// privateIPSpace contains the private address ranges of RFC 1918 and the
+// unique local addresses of RFC 4193
+var privateIPSpace = parseCIDRs(
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"fc00::/7",
+)
+
+// reservedIPSpace contains the special purpose ranges of the IANA IPv4 and
+// IPv6 Special-Purpose Address Registries that are not globally reachable and
+// are not covered by net.IP.IsGlobalUnicast, BR §7.1.4.2.1
+var reservedIPSpace = parseCIDRs(
+	"0.0.0.0/8",       // This network, RFC 791
+	"100.64.0.0/10",   // Shared address space, RFC 6598
+	"192.0.0.0/24",    // IETF protocol assignments, RFC 6890
+	"192.0.2.0/24",    // Documentation (TEST-NET-1), RFC 5737
+	"198.18.0.0/15",   // Benchmarking, RFC 2544
+	"198.51.100.0/24", // Documentation (TEST-NET-2), RFC 5737
+	"203.0.113.0/24",  // Documentation (TEST-NET-3), RFC 5737
+	"240.0.0.0/4",     // Reserved, RFC 1112
+	"64:ff9b:1::/48",  // IPv4-IPv6 translation, RFC 8215
+	"100::/64",        // Discard-only, RFC 6666
+	"2001::/23",       // IETF protocol assignments, RFC 2928
+	"2001:db8::/32",   // Documentation, RFC 3849
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
+
 // checkInternalIP verifies is an IP address in a registered internal range.
-// TODO: Check if we need to verify any IPv6 ranges
-// TODO: We should also check for special purpose IP ranges, we might want to do that in a specific function
 func checkInternalIP(ip net.IP) bool {
-	var privIPSpace []net.IPNet
-	// 10.0.0.0/8
-	privIPSpace = append(privIPSpace, net.IPNet{
-		IP:   net.IP{0xa, 0x0, 0x0, 0x0},
-		Mask: net.IPMask{0xff, 0x0, 0x0, 0x0},
-	})
-	// 172.16.0.0/12"
-	privIPSpace = append(privIPSpace, net.IPNet{
-		IP:   net.IP{0xac, 0x10, 0x0, 0x0},
-		Mask: net.IPMask{0xff, 0xf0, 0x0, 0x0},
-	})
-	// 192.168.0.0/16
-	privIPSpace = append(privIPSpace, net.IPNet{
-		IP:   net.IP{0xc0, 0xa8, 0x0, 0x0},
-		Mask: net.IPMask{0xff, 0xff, 0x0, 0x0},
-	})
+	return inIPSpace(privateIPSpace, ip)
+}
+
+// checkReservedIP verifies if an IP address is in a special purpose range that
+// can't be validated for a publicly trusted certificate
+func checkReservedIP(ip net.IP) bool {
+	return inIPSpace(reservedIPSpace, ip)
+}
 
-	for _, ipSpace := range privIPSpace {
-		if ipSpace.Contains(ip) {
+func inIPSpace(space []*net.IPNet, ip net.IP) bool {
+	for _, n := range space {
+		if n.Contains(ip) {
 			return true
 		}
 	}
@@ -11,7 +11,15 @@ import (
 const checkName = "Issuer DN Check"
 
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/issuerdn",
+		Description: "Issuer DN matches the subject DN of the issuing CA",
+		Citation:    "RFC 5280 §4.1.2.4, BR §7.1.4.1",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate Issuer Distinguished Name field MUST match the Subject DN of the Issuing CA":  "CERTLINT_ISS_001",
+		"Certificate Subject DN is equal to the Issuer DN, but the certificate is not self-signed": "CERTLINT_ISS_002",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
@@ -23,5 +31,13 @@ func Check(d *certdata.Data) *errors.Errors {
 		return e
 	}
 
+	// A leaf certificate with the subject of its issuer is almost always a
+	// mistake, unless the certificate is self-signed
+	if !d.Cert.IsCA && bytes.Equal(d.Cert.RawSubject, d.Cert.RawIssuer) {
+		if d.Cert.CheckSignature(d.Cert.SignatureAlgorithm, d.Cert.RawTBSCertificate, d.Cert.Signature) != nil {
+			e.Warning("Certificate Subject DN is equal to the Issuer DN, but the certificate is not self-signed")
+		}
+	}
+
 	return e
 }
@@ -3,6 +3,7 @@ package keyusage
 import (
 	"crypto/dsa"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 
@@ -14,7 +15,16 @@ import (
 const checkName = "Key Usage Check"
 
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/keyusage",
+		Description: "Key usage is set and matches the type of the public key",
+		Citation:    "RFC 5280 §4.2.1.3",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate has no key usage set":                                            "CERTLINT_KU_001",
+		"Certificate has key usage %s set":                                            "CERTLINT_KU_002",
+		"Certificate has key usage %s set, which is impossible with an %s public key": "CERTLINT_KU_003",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
@@ -27,18 +37,29 @@ func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 	var forbidden []x509.KeyUsage
 
+	// Key usages the public key algorithm can't perform at all are most likely
+	// a misconfigured certificate template
+	var impossible []x509.KeyUsage
+	var algorithm string
+
 	// Source
 	// https://github.com/awslabs/certlint/blob/master/lib/certlint/extensions/keyusage.rb
 	switch d.Cert.PublicKey.(type) {
 	case *rsa.PublicKey:
-		forbidden = []x509.KeyUsage{
+		algorithm = "RSA"
+		impossible = []x509.KeyUsage{
 			x509.KeyUsageKeyAgreement,
+		}
+		forbidden = []x509.KeyUsage{
 			x509.KeyUsageEncipherOnly,
 			x509.KeyUsageDecipherOnly,
 		}
 	case *ecdsa.PublicKey:
-		forbidden = []x509.KeyUsage{
+		algorithm = "EC"
+		impossible = []x509.KeyUsage{
 			x509.KeyUsageKeyEncipherment,
+		}
+		forbidden = []x509.KeyUsage{
 			x509.KeyUsageDataEncipherment,
 		}
 	case *dsa.PublicKey:
@@ -58,6 +79,20 @@ func Check(d *certdata.Data) *errors.Errors {
 		//   x509.KeyUsageCertSign,
 		//   x509.KeyUsageCRLSign,
 		// }
+	case ed25519.PublicKey:
+		// EdDSA keys can only be used for signatures
+		//
+		// https://tools.ietf.org/html/rfc8410#section-5
+		algorithm = "EdDSA"
+		impossible = []x509.KeyUsage{
+			x509.KeyUsageKeyEncipherment,
+		}
+		forbidden = []x509.KeyUsage{
+			x509.KeyUsageDataEncipherment,
+			x509.KeyUsageKeyAgreement,
+			x509.KeyUsageEncipherOnly,
+			x509.KeyUsageDecipherOnly,
+		}
 	}
 
 	// If we have not defined this certificate as a CA certificate, the following
@@ -72,6 +107,12 @@ func Check(d *certdata.Data) *errors.Errors {
 		forbidden = append(forbidden, x509.KeyUsageCRLSign)
 	}
 
+	for _, iku := range impossible {
+		if d.Cert.KeyUsage&iku != 0 {
+			e.Warning("Certificate has key usage %s set, which is impossible with an %s public key", keyUsageString(iku), algorithm)
+		}
+	}
+
 	// Check if there are any forbidden key usages set
 	for _, fku := range forbidden {
 		if d.Cert.KeyUsage&fku != 0 {
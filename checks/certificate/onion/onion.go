@@ -0,0 +1,133 @@
+package onion
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/base32"
+	"strings"
+
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const checkName = "Onion Names Check"
+
+const (
+	// v2AddressLen is the length of the obsolete 80 bit v2 onion addresses
+	v2AddressLen = 16
+	// v3AddressLen is the length of the base32 encoded public key, checksum
+	// and version of a v3 onion address
+	v3AddressLen = 56
+	// v3Version is the version byte of a v3 onion address
+	v3Version = 0x03
+)
+
+// oidTorServiceDescriptor is the cabf-TorServiceDescriptor extension of onion
+// names validated with the method of BR Appendix C.1
+var oidTorServiceDescriptor = asn1.ObjectIdentifier{2, 23, 140, 1, 31}
+
+// descriptorLifetime is the maximum lifetime of certificates with onion names
+// validated with the TorServiceDescriptor method, BR Appendix C.1
+var descriptorLifetime = certdata.Lifetime{Months: 15, Desc: "15 months"}
+
+// onionEncoding is the lower case base32 alphabet of onion addresses
+var onionEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+func init() {
+	filter := &checks.Filter{
+		Type: []string{"DV", "OV", "IV", "EV"},
+	}
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/onion",
+		Description: "Onion domain names are valid version 3 onion addresses",
+		Citation:    "RFC 7686, BR Appendix C, Tor rend-spec-v3 §6",
+	}, filter, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate subjectAltName '%s' contains an obsolete version 2 onion address":       "CERTLINT_ONION_001",
+		"Certificate subjectAltName '%s' is not a valid onion address":                       "CERTLINT_ONION_002",
+		"Certificate subjectAltName '%s' contains an onion address with an invalid checksum": "CERTLINT_ONION_003",
+		"Certificate subjectAltName '%s' contains an onion address of unknown version %d":    "CERTLINT_ONION_004",
+		"Certificate contains the onion domain name '%s' without an onion address":           "CERTLINT_ONION_005",
+		"Certificate with onion names and a TorServiceDescriptor LifeTime exceeds %s":        "CERTLINT_ONION_006",
+	})
+}
+
+// Check verifies the structure of the onion domain names in the subjectAltName,
+// only version 3 addresses may be included in certificates since the version 2
+// addresses were retired by the Tor Project and BR Appendix C.
+//
+// https://tools.ietf.org/html/rfc7686
+func Check(d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	var onion bool
+	for _, n := range d.Cert.DNSNames {
+		name := strings.TrimSuffix(strings.ToLower(n), ".")
+		if !strings.HasSuffix(name, ".onion") && name != "onion" {
+			continue
+		}
+		onion = true
+
+		// The address is the label left of .onion, labels in front of it are
+		// subdomains of the onion service
+		labels := strings.Split(strings.TrimSuffix(name, ".onion"), ".")
+		address := labels[len(labels)-1]
+		if name == "onion" || len(address) == 0 || address == "*" {
+			e.Err("Certificate contains the onion domain name '%s' without an onion address", n)
+			continue
+		}
+
+		switch len(address) {
+		case v2AddressLen:
+			e.Err("Certificate subjectAltName '%s' contains an obsolete version 2 onion address", n)
+		case v3AddressLen:
+			checkV3Address(e, n, address)
+		default:
+			e.Err("Certificate subjectAltName '%s' is not a valid onion address", n)
+		}
+	}
+
+	if onion && hasExtension(d, oidTorServiceDescriptor) && d.Cert.NotAfter.After(descriptorLifetime.NotAfter(d.Cert.NotBefore)) {
+		e.Err("Certificate with onion names and a TorServiceDescriptor LifeTime exceeds %s", descriptorLifetime.Desc)
+	}
+
+	return e
+}
+
+// checkV3Address verifies the checksum and version of a v3 onion address,
+// which encodes PUBKEY | CHECKSUM | VERSION where CHECKSUM is the start of
+// SHA3-256(".onion checksum" | PUBKEY | VERSION)
+func checkV3Address(e *errors.Errors, name, address string) {
+	b, err := onionEncoding.DecodeString(address)
+	if err != nil || len(b) != 35 {
+		e.Err("Certificate subjectAltName '%s' is not a valid onion address", name)
+		return
+	}
+
+	pubkey, checksum, version := b[:32], b[32:34], b[34]
+	if version != v3Version {
+		e.Err("Certificate subjectAltName '%s' contains an onion address of unknown version %d", name, version)
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(".onion checksum")
+	buf.Write(pubkey)
+	buf.WriteByte(version)
+	sum := sha3.Sum256(buf.Bytes())
+	if !bytes.Equal(sum[:2], checksum) {
+		e.Err("Certificate subjectAltName '%s' contains an onion address with an invalid checksum", name)
+	}
+}
+
+func hasExtension(d *certdata.Data, oid asn1.ObjectIdentifier) bool {
+	for _, ext := range d.Cert.Extensions {
+		if ext.Id.Equal(oid) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package policyidentifiers
+
+import (
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "Certificate Policies Check"
+
+func init() {
+	filter := &checks.Filter{
+		Type: []string{"DV", "OV", "EV"},
+	}
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/policyidentifiers",
+		Description: "Certificate contains certificate policies",
+		Citation:    "RFC 5280 §4.2.1.4, BR §7.1.6.4",
+	}, filter, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate doesn't contain any certificate policies": "CERTLINT_POL_001",
+	})
+}
+
+// Check verifies that a leaf certificate contains the certificate policies it
+// has been issued under.
+func Check(d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	if !d.Cert.IsCA && len(d.Cert.PolicyIdentifiers) == 0 {
+		e.Warning("Certificate doesn't contain any certificate policies")
+	}
+
+	return e
+}
@@ -0,0 +1,183 @@
+package psd2
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"regexp"
+
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "PSD2 Check"
+
+// Required reports certificates without the PSD2 QcStatement, it is set by
+// the etsi-psd2 profile. The statement is checked whenever it is present.
+var Required bool
+
+var (
+	oidQCStatements      = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 3}
+	oidQcCompliance      = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 1}
+	oidPSD2              = asn1.ObjectIdentifier{0, 4, 0, 19495, 2}
+	oidOrganizationID    = asn1.ObjectIdentifier{2, 5, 4, 97}
+	oidRolePSPAS         = asn1.ObjectIdentifier{0, 4, 0, 19495, 1, 1}
+	oidRolePSPPI         = asn1.ObjectIdentifier{0, 4, 0, 19495, 1, 2}
+	oidRolePSPAI         = asn1.ObjectIdentifier{0, 4, 0, 19495, 1, 3}
+	oidRolePSPIC         = asn1.ObjectIdentifier{0, 4, 0, 19495, 1, 4}
+	roleNames            = map[string]string{oidRolePSPAS.String(): "PSP_AS", oidRolePSPPI.String(): "PSP_PI", oidRolePSPAI.String(): "PSP_AI", oidRolePSPIC.String(): "PSP_IC"}
+	ncaIDFormat          = regexp.MustCompile(`^[A-Z]{2}-[A-Z]{2,8}$`)
+	organizationIDFormat = regexp.MustCompile(`^PSD([A-Z]{2}-[A-Z]{2,8})-.+$`)
+)
+
+type qcStatement struct {
+	ID   asn1.ObjectIdentifier
+	Info asn1.RawValue `asn1:"optional"`
+}
+
+// psd2QcType is the statement info of the PSD2 QcStatement, TS 119 495 §5.1
+type psd2QcType struct {
+	Roles   []roleOfPSP
+	NCAName string `asn1:"utf8"`
+	NCAId   string `asn1:"utf8"`
+}
+
+type roleOfPSP struct {
+	ID   asn1.ObjectIdentifier
+	Name string `asn1:"utf8"`
+}
+
+func init() {
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/psd2",
+		Description: "PSD2 QcStatement, roles of the PSP and organizationIdentifier of PSD2 qualified certificates",
+		Citation:    "ETSI TS 119 495 §5.1, §5.2",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate does not contain the PSD2 QcStatement":                   "CERTLINT_PSD2_001",
+		"Failed to parse PSD2 QcStatement: %s":                                "CERTLINT_PSD2_002",
+		"PSD2 QcStatement without QcCompliance statement":                     "CERTLINT_PSD2_003",
+		"PSD2 QcStatement contains no role of the PSP":                        "CERTLINT_PSD2_004",
+		"PSD2 QcStatement contains unknown role %s":                           "CERTLINT_PSD2_005",
+		"PSD2 role %s has name '%s', expected '%s'":                           "CERTLINT_PSD2_006",
+		"PSD2 QcStatement contains an empty NCAName":                          "CERTLINT_PSD2_007",
+		"PSD2 NCAId '%s' is not formatted as country code and NCA identifier": "CERTLINT_PSD2_008",
+		"Subject does not contain an organizationIdentifier":                  "CERTLINT_PSD2_009",
+		"organizationIdentifier '%s' is not formatted as PSDXX-YYYY-ZZZZ":     "CERTLINT_PSD2_010",
+		"organizationIdentifier '%s' does not match the NCAId '%s'":           "CERTLINT_PSD2_011",
+		"PSD2 QcStatement attribute exceeds 256 characters":                   "CERTLINT_PSD2_012",
+	})
+}
+
+// Check verifies the PSD2 QcStatement and the organizationIdentifier of
+// qualified certificates for payment service providers
+//
+// https://www.etsi.org/deliver/etsi_ts/119400_119499/119495/
+func Check(d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	statement, compliance, err := findStatement(d)
+	if err != nil {
+		e.Err("Failed to parse PSD2 QcStatement: %s", err.Error())
+		return e
+	}
+	if statement == nil {
+		if Required {
+			e.Err("Certificate does not contain the PSD2 QcStatement")
+		}
+		return e
+	}
+
+	var info psd2QcType
+	if rest, err := asn1.Unmarshal(statement.Info.FullBytes, &info); err != nil {
+		e.Err("Failed to parse PSD2 QcStatement: %s", err.Error())
+		return e
+	} else if len(rest) > 0 {
+		e.Err("Failed to parse PSD2 QcStatement: %s", "trailing data")
+		return e
+	}
+
+	// PSD2 certificates are qualified website or seal certificates, §4.1
+	if !compliance {
+		e.Err("PSD2 QcStatement without QcCompliance statement")
+	}
+
+	if len(info.Roles) == 0 {
+		e.Err("PSD2 QcStatement contains no role of the PSP")
+	}
+	for _, r := range info.Roles {
+		name, ok := roleNames[r.ID.String()]
+		if !ok {
+			e.Err("PSD2 QcStatement contains unknown role %s", r.ID.String())
+			continue
+		}
+		if r.Name != name {
+			e.Err("PSD2 role %s has name '%s', expected '%s'", r.ID.String(), r.Name, name)
+		}
+	}
+
+	if len(info.NCAName) == 0 {
+		e.Err("PSD2 QcStatement contains an empty NCAName")
+	}
+	if len(info.NCAName) > 256 || len(info.NCAId) > 256 {
+		e.Err("PSD2 QcStatement attribute exceeds 256 characters")
+	}
+	if !ncaIDFormat.MatchString(info.NCAId) {
+		e.Err("PSD2 NCAId '%s' is not formatted as country code and NCA identifier", info.NCAId)
+	}
+
+	// The organizationIdentifier contains the NCAId and the PSP identifier, §5.2.1
+	orgID, ok := organizationIdentifier(d)
+	if !ok {
+		e.Err("Subject does not contain an organizationIdentifier")
+		return e
+	}
+	m := organizationIDFormat.FindStringSubmatch(orgID)
+	switch {
+	case m == nil:
+		e.Err("organizationIdentifier '%s' is not formatted as PSDXX-YYYY-ZZZZ", orgID)
+	case m[1] != info.NCAId:
+		e.Err("organizationIdentifier '%s' does not match the NCAId '%s'", orgID, info.NCAId)
+	}
+
+	return e
+}
+
+// findStatement returns the PSD2 QcStatement and whether the QcCompliance
+// statement is present
+func findStatement(d *certdata.Data) (*qcStatement, bool, error) {
+	for _, ext := range d.Cert.Extensions {
+		if !ext.Id.Equal(oidQCStatements) {
+			continue
+		}
+
+		var statements []qcStatement
+		if _, err := asn1.Unmarshal(ext.Value, &statements); err != nil {
+			return nil, false, fmt.Errorf("QCStatements extension: %s", err.Error())
+		}
+
+		var psd2 *qcStatement
+		var compliance bool
+		for i, s := range statements {
+			switch {
+			case s.ID.Equal(oidPSD2):
+				psd2 = &statements[i]
+			case s.ID.Equal(oidQcCompliance):
+				compliance = true
+			}
+		}
+		return psd2, compliance, nil
+	}
+	return nil, false, nil
+}
+
+// organizationIdentifier returns the organizationIdentifier of the subject
+func organizationIdentifier(d *certdata.Data) (string, bool) {
+	for _, n := range d.Cert.Subject.Names {
+		if n.Type.Equal(oidOrganizationID) {
+			s, ok := n.Value.(string)
+			return s, ok
+		}
+	}
+	return "", false
+}
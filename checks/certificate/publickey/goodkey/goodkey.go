@@ -44,6 +44,7 @@ type KeyPolicy struct {
 	AllowRSA           bool // Whether RSA keys should be allowed.
 	AllowECDSANISTP256 bool // Whether ECDSA NISTP256 keys should be allowed.
 	AllowECDSANISTP384 bool // Whether ECDSA NISTP384 keys should be allowed.
+	CheckRSAExponent   bool // Whether the RSA public exponent should be checked.
 }
 
 // NewKeyPolicy returns a KeyPolicy that allows RSA, ECDSA256 and ECDSA384.
@@ -52,6 +53,7 @@ func NewKeyPolicy() KeyPolicy {
 		AllowRSA:           true,
 		AllowECDSANISTP256: true,
 		AllowECDSANISTP384: true,
+		CheckRSAExponent:   true,
 	}
 }
 
@@ -181,7 +183,7 @@ func (policy *KeyPolicy) goodCurve(c elliptic.Curve) (err error) {
 	case policy.AllowECDSANISTP384 && params == elliptic.P384().Params():
 		return nil
 	default:
-		return fmt.Errorf(fmt.Sprintf("ECDSA curve %v not allowed", params.Name))
+		return fmt.Errorf("ECDSA curve %v not allowed", params.Name)
 	}
 }
 
@@ -197,12 +199,12 @@ func (policy *KeyPolicy) goodKeyRSA(key rsa.PublicKey) (err error) {
 	modulus := key.N
 	modulusBitLen := modulus.BitLen()
 	if modulusBitLen < 2048 {
-		return fmt.Errorf(fmt.Sprintf("Key too small: %d", modulusBitLen))
+		return fmt.Errorf("Key too small: %d", modulusBitLen)
 	}
 	// Bit lengths that are not a multiple of 8 may cause problems on some
 	// client implementations.
 	if modulusBitLen%8 != 0 {
-		return fmt.Errorf(fmt.Sprintf("Key length wasn't a multiple of 8: %d", modulusBitLen))
+		return fmt.Errorf("Key length wasn't a multiple of 8: %d", modulusBitLen)
 	}
 	// The CA SHALL confirm that the value of the public exponent is an
 	// odd number equal to 3 or more. Additionally, the public exponent
@@ -210,15 +212,18 @@ func (policy *KeyPolicy) goodKeyRSA(key rsa.PublicKey) (err error) {
 	// NOTE: rsa.PublicKey cannot represent an exponent part greater than
 	// 2^32 - 1 or 2^64 - 1, because it stores E as an integer. So we
 	// don't need to check the upper bound.
-	if (key.E%2) == 0 || key.E < ((1<<16)+1) {
-		return fmt.Errorf(fmt.Sprintf("Key exponent should be odd and >2^16: %d", key.E))
+	if policy.CheckRSAExponent && ((key.E%2) == 0 || key.E < ((1<<16)+1)) {
+		return fmt.Errorf("Key exponent should be odd and >2^16: %d", key.E)
 	}
 	// The modulus SHOULD also have the following characteristics: an odd
 	// number, not the power of a prime, and have no factors smaller than 752.
-	// TODO: We don't yet check for "power of a prime."
+	// TODO: We only check for squares, not for other powers of a prime.
 	if checkSmallPrimes(modulus) {
 		return fmt.Errorf("Key divisible by small prime")
 	}
+	if root := new(big.Int).Sqrt(modulus); root.Mul(root, root).Cmp(modulus) == 0 {
+		return fmt.Errorf("Key modulus is a perfect square")
+	}
 
 	return nil
 }
@@ -1,6 +1,7 @@
 package publickey
 
 import (
+	"crypto/rsa"
 	"strings"
 
 	"github.com/weyhmueller/certlint/certdata"
@@ -12,19 +13,52 @@ import (
 const checkName = "Public Key Check"
 
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/publickey",
+		Description: "Public key has an allowed algorithm, size and RSA public exponent",
+		Citation:    "BR §6.1.5, BR §6.1.6",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate %s": "CERTLINT_KEY_001",
+		"Certificate RSA public exponent %d is even":            "CERTLINT_KEY_002",
+		"Certificate RSA public exponent %d is less than 3":     "CERTLINT_KEY_003",
+		"Certificate RSA public exponent %d is less than 65537": "CERTLINT_KEY_004",
+	})
 }
 
+// minExponent is the smallest recommended RSA public exponent, 2^16+1
+const minExponent = 1<<16 + 1
+
 // Check performs a strict verification on the extension according to the standard(s)
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
+	// The RSA exponent is checked on the encoded value below, with a severity
+	// per requirement
 	gkp := goodkey.NewKeyPolicy()
+	gkp.CheckRSAExponent = false
 	err := gkp.GoodKey(d.Cert.PublicKey)
 	if err != nil {
 		e.Err("Certificate %s", strings.ToLower(err.Error()))
-		return e
+	}
+
+	if key, ok := d.Cert.PublicKey.(*rsa.PublicKey); ok {
+		checkRSAExponent(key.E, e)
 	}
 
 	return e
 }
+
+// checkRSAExponent verifies the public exponent is odd and at least 3, BR
+// §6.1.6. Exponents exceeding 2^64-1 are reported by the asn1 linter as the
+// x509 parser rejects them.
+func checkRSAExponent(exp int, e *errors.Errors) {
+	switch {
+	case exp < 3:
+		e.Err("Certificate RSA public exponent %d is less than 3", exp)
+	case exp%2 == 0:
+		e.Err("Certificate RSA public exponent %d is even", exp)
+	case exp < minExponent:
+		e.Warning("Certificate RSA public exponent %d is less than 65537", exp)
+	}
+}
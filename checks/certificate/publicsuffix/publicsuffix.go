@@ -1,7 +1,6 @@
 package publicsuffix
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/weyhmueller/certlint/certdata"
@@ -17,23 +16,32 @@ func init() {
 	filter := &checks.Filter{
 		Type: []string{"DV", "OV", "IV", "EV"},
 	}
-	checks.RegisterCertificateCheck(checkName, filter, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/publicsuffix",
+		Description: "Names are not equal to a public suffix",
+		Citation:    "BR §3.2.2.6",
+	}, filter, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate CommonName '%s' equals '%s' from the public suffix list":     "CERTLINT_PSL_001",
+		"Certificate subjectAltName '%s' equals '%s' from the public suffix list": "CERTLINT_PSL_002",
+	})
 }
 
-// Check performs a strict verification on the extension according to the standard(s)
+// Check performs a strict verification on the extension according to the standard(s),
+// wildcards directly under a public suffix are reported by the Wildcard(s) Check
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
 	if len(d.Cert.Subject.CommonName) > 0 {
 		suffix, official := psl.PublicSuffix(strings.ToLower(d.Cert.Subject.CommonName))
-		if official && (fmt.Sprintf("*.%s", suffix) == d.Cert.Subject.CommonName || suffix == d.Cert.Subject.CommonName) {
+		if official && suffix == strings.ToLower(d.Cert.Subject.CommonName) {
 			e.Err("Certificate CommonName '%s' equals '%s' from the public suffix list", d.Cert.Subject.CommonName, suffix)
 		}
 	}
 
 	for _, n := range d.Cert.DNSNames {
 		suffix, official := psl.PublicSuffix(strings.ToLower(n))
-		if official && (fmt.Sprintf("*.%s", suffix) == n || suffix == n) {
+		if official && suffix == strings.ToLower(n) {
 			e.Err("Certificate subjectAltName '%s' equals '%s' from the public suffix list", n, suffix)
 		}
 	}
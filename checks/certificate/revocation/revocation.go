@@ -10,8 +10,27 @@ import (
 
 const checkName = "Certificate Revocation Information Check"
 
+// MinURLs is the minimum number of OCSP servers or CRL distribution points a
+// publicly trusted certificate should contain for redundancy, 0 disables the
+// notice.
+var MinURLs = 2
+
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/revocation",
+		Description: "Certificate contains valid and redundant OCSP servers or CRL distribution points",
+		Citation:    "RFC 5280 §4.2.1.13, BR §7.1.2.3",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate contains no CRL or OCSP server":                                                "CERTLINT_REV_001",
+		"Certificate contains fewer than %d OCSP servers or CRL distribution points, no redundancy": "CERTLINT_REV_002",
+		"Certificate contains an invalid CRL (%s)":                                                  "CERTLINT_REV_003",
+		"Certificate contains a CRL with an non-preferred scheme (%s)":                              "CERTLINT_REV_004",
+		"Certificate contains an invalid OCSP server (%s)":                                          "CERTLINT_REV_005",
+		"Certificate contains an OCSP server using https, OCSP must be served over http (%s)":       "CERTLINT_REV_006",
+		"Certificate contains a OCSP server with an non-preferred scheme (%s)":                      "CERTLINT_REV_007",
+		"Certificate contains a CRL using https, CRLs should be served over http (%s)":              "CERTLINT_REV_008",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
@@ -23,11 +42,21 @@ func Check(d *certdata.Data) *errors.Errors {
 		return e
 	}
 
+	// Advise to publish more than one OCSP server or CRL for redundancy
+	switch d.Type {
+	case "DV", "OV", "IV", "EV":
+		if len(d.Cert.CRLDistributionPoints) < MinURLs && len(d.Cert.OCSPServer) < MinURLs {
+			e.Notice("Certificate contains fewer than %d OCSP servers or CRL distribution points, no redundancy", MinURLs)
+		}
+	}
+
 	// Check CRL information
 	for _, crl := range d.Cert.CRLDistributionPoints {
 		l, err := url.Parse(crl)
 		if err != nil {
 			e.Err("Certificate contains an invalid CRL (%s)", crl)
+		} else if l.Scheme == "https" {
+			e.Warning("Certificate contains a CRL using https, CRLs should be served over http (%s)", crl)
 		} else if l.Scheme != "http" {
 			e.Err("Certificate contains a CRL with an non-preferred scheme (%s)", l.Scheme)
 		}
@@ -37,7 +66,11 @@ func Check(d *certdata.Data) *errors.Errors {
 	for _, server := range d.Cert.OCSPServer {
 		s, err := url.Parse(server)
 		if err != nil {
-			e.Err("Certificate contains an invalid OCSP server (%s)", s)
+			e.Err("Certificate contains an invalid OCSP server (%s)", server)
+		} else if s.Scheme == "https" {
+			// OCSP over https is not supported by clients and could result in a
+			// loop when validating the certificate of the OCSP server
+			e.Err("Certificate contains an OCSP server using https, OCSP must be served over http (%s)", server)
 		} else if s.Scheme != "http" {
 			e.Err("Certificate contains a OCSP server with an non-preferred scheme (%s)", s.Scheme)
 		}
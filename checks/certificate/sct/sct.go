@@ -0,0 +1,87 @@
+package sct
+
+import (
+	"encoding/base64"
+
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/ctlogs"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "SCT Signature Check"
+
+func init() {
+	filter := &checks.Filter{
+		Type:        []string{"DV", "OV", "EV"},
+		SkipPrecert: true,
+	}
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/sct",
+		Description: "Embedded SCTs are signed by known CT logs that were not retired when the SCT was issued",
+		Citation:    "RFC 6962 §3.2, §3.3",
+	}, filter, Check)
+	errors.RegisterCodes(map[string]string{
+		"Failed to parse embedded SCT list: %s":                   "CERTLINT_SCT_001",
+		"SCT from unknown CT log %s":                              "CERTLINT_SCT_002",
+		"SCT from %s issued after the log was retired":            "CERTLINT_SCT_003",
+		"SCT from retired CT log %s":                              "CERTLINT_SCT_004",
+		"SCT from rejected CT log %s":                             "CERTLINT_SCT_005",
+		"Invalid SCT signature from %s: %s":                       "CERTLINT_SCT_006",
+		"Issuer certificate unknown, SCT signatures not verified": "CERTLINT_SCT_007",
+		"No CT log list available, SCT signatures not verified":   "CERTLINT_SCT_008",
+	})
+}
+
+// Check verifies the signatures of the embedded SCTs with the keys of the logs
+// in the CT log list
+//
+// https://tools.ietf.org/html/rfc6962#section-3.2
+func Check(d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	scts, err := ctlogs.EmbeddedSCTs(d.Cert)
+	if err != nil {
+		e.Err("Failed to parse embedded SCT list: %s", err.Error())
+		return e
+	}
+	if len(scts) == 0 {
+		return e
+	}
+
+	logs := ctlogs.Default()
+	if logs.Len() == 0 {
+		e.Info("No CT log list available, SCT signatures not verified")
+		return e
+	}
+	if d.Issuer == nil {
+		e.Info("Issuer certificate unknown, SCT signatures not verified")
+	}
+
+	for _, s := range scts {
+		log := logs.Lookup(s.LogID)
+		if log == nil {
+			e.Warning("SCT from unknown CT log %s", base64.StdEncoding.EncodeToString(s.LogID[:]))
+			continue
+		}
+
+		switch log.State {
+		case ctlogs.Retired:
+			if s.Time().After(log.StateSince) {
+				e.Err("SCT from %s issued after the log was retired", log.Description)
+			} else {
+				e.Notice("SCT from retired CT log %s", log.Description)
+			}
+		case ctlogs.Rejected:
+			e.Err("SCT from rejected CT log %s", log.Description)
+		}
+
+		if d.Issuer != nil {
+			if err := ctlogs.VerifyEmbedded(s, log, d.Cert, d.Issuer); err != nil {
+				e.Err("Invalid SCT signature from %s: %s", log.Description, err.Error())
+			}
+		}
+	}
+
+	return e
+}
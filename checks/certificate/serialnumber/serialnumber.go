@@ -2,7 +2,6 @@ package serialnumber
 
 import (
 	"math/big"
-	"time"
 
 	"github.com/weyhmueller/certlint/certdata"
 	"github.com/weyhmueller/certlint/checks"
@@ -12,9 +11,28 @@ import (
 const checkName = "Certificate Serial Number Check"
 
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/serialnumber",
+		Description: "Serial number is positive and contains enough random data",
+		Citation:    "RFC 5280 §4.1.2.2, BR §7.1",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate serial number MUST be a positive integer (%d)":                                                "CERTLINT_SN_001",
+		"Certificate serial number should be %d bits but contains %d bits":                                         "CERTLINT_SN_002",
+		"Certificate serial number must contain at least %d bits of unpredictable random data, found only %d bits": "CERTLINT_SN_003",
+		"Certificate serial number is %d octets, which exceeds the maximum of 20 octets":                           "CERTLINT_SN_004",
+		"Certificate serial number contains a run of %d identical bytes, which is unlikely for random data":        "CERTLINT_SN_005",
+	})
 }
 
+// maxSerialOctets is the maximum length of the encoded serial number, RFC 5280
+// section 4.1.2.2
+const maxSerialOctets = 20
+
+// maxByteRun is the longest run of identical bytes expected in a random serial
+// number, a run of 4 bytes occurs with a probability of about 2^-24 per byte.
+const maxByteRun = 3
+
 // Check performs a strict verification on the extension according to the standard(s)
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
@@ -23,22 +41,47 @@ func Check(d *certdata.Data) *errors.Errors {
 		e.Err("Certificate serial number MUST be a positive integer (%d)", d.Cert.SerialNumber)
 	}
 
+	// The encoding includes a leading zero byte when the high bit is set
+	if n := d.Cert.SerialNumber.BitLen()/8 + 1; n > maxSerialOctets {
+		e.Err("Certificate serial number is %d octets, which exceeds the maximum of 20 octets", n)
+	}
+
 	// Remaining checks are not relevant for CA certificates
 	if d.Cert.IsCA {
 		return e
 	}
 
-	// https://cabforum.org/2016/07/08/ballot-164/
-	if d.Cert.NotBefore.After(time.Date(2016, 9, 30, 0, 0, 0, 0, time.UTC)) {
-		if d.Cert.SerialNumber.BitLen() < 64 {
-			e.Err("Certificate serial number should be 64 bits but contains %d bits", d.Cert.SerialNumber.BitLen())
+	r := d.Requirements()
+	if r.SerialBits > 0 {
+		if d.Cert.SerialNumber.BitLen() < r.SerialBits {
+			e.Err("Certificate serial number should be %d bits but contains %d bits", r.SerialBits, d.Cert.SerialNumber.BitLen())
 		}
-	} else {
+	} else if d.Cert.SerialNumber.BitLen() < r.SerialEntropyBits {
 		// all new end-entity certificates must contain at least 20 bits of unpredictable random data (preferably in the serial number).
-		if d.Cert.SerialNumber.BitLen() < 20 {
-			e.Warning("Certificate serial number must contain at least 20 bits of unpredictable random data, found only %d bits", d.Cert.SerialNumber.BitLen())
-		}
+		e.Warning("Certificate serial number must contain at least %d bits of unpredictable random data, found only %d bits", r.SerialEntropyBits, d.Cert.SerialNumber.BitLen())
+	}
+
+	// Serial numbers with a counter or timestamp padded to the required length
+	// contain long runs of zero bytes
+	if run := longestRun(d.Cert.SerialNumber.Bytes()); run > maxByteRun {
+		e.Notice("Certificate serial number contains a run of %d identical bytes, which is unlikely for random data", run)
 	}
 
-	return nil
+	return e
+}
+
+// longestRun returns the length of the longest run of identical bytes in b
+func longestRun(b []byte) int {
+	var max, run int
+	for i := range b {
+		if i > 0 && b[i] == b[i-1] {
+			run++
+		} else {
+			run = 1
+		}
+		if run > max {
+			max = run
+		}
+	}
+	return max
 }
@@ -1,8 +1,9 @@
 package signaturealgorithm
 
 import (
+	"bytes"
+	"crypto/ecdsa"
 	"crypto/x509"
-	"time"
 
 	"github.com/weyhmueller/certlint/certdata"
 	"github.com/weyhmueller/certlint/checks"
@@ -15,30 +16,86 @@ func init() {
 	filter := &checks.Filter{
 		Type: []string{"DV", "OV", "IV", "EV"},
 	}
-	checks.RegisterCertificateCheck(checkName, filter, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/signaturealgorithm",
+		Description: "Signature algorithm is not MD5, MD2 or SHA1 and not weaker than the signing key",
+		Citation:    "BR §7.1.3",
+	}, filter, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate is signed using %s, which is weaker than the %s curve of the signing key": "CERTLINT_SIG_001",
+		"Certificate is using SHA1, but is issued on/after 1 Jan 2016":                         "CERTLINT_SIG_002",
+		"Certificate is using SHA1, but is still valid on/after %s":                            "CERTLINT_SIG_003",
+		"Certificate is using SHA1, which was allowed at issuance":                             "CERTLINT_SIG_004",
+		"Certificate is signed using %s, which is not allowed since 1 Jul 2012":                "CERTLINT_SIG_005",
+		"Certificate is signed using %s, which is vulnerable to collision attacks":             "CERTLINT_SIG_006",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
-	// Check if we use SHA1 or less (MD5, MD2)
-	if d.Cert.SignatureAlgorithm > x509.SHA1WithRSA &&
-		d.Cert.SignatureAlgorithm != x509.DSAWithSHA1 &&
-		d.Cert.SignatureAlgorithm != x509.ECDSAWithSHA1 {
-		return e
+	// The hash should not be weaker than the curve of the signing key
+	if key := signingKey(d); key != nil {
+		hs, ok := ecdsaHashStrength[d.Cert.SignatureAlgorithm]
+		if cs, known := curveStrength[key.Curve.Params().Name]; ok && known && hs < cs {
+			e.Notice("Certificate is signed using %s, which is weaker than the %s curve of the signing key", d.Cert.SignatureAlgorithm, key.Curve.Params().Name)
+		}
 	}
 
-	if d.Cert.NotBefore.After(time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)) {
-		e.Err("Certificate is using SHA1, but is issued on/after 1 Jan 2016")
-		return e
+	// Apply the sunset rules that were in effect at issuance, the severity
+	// depends on whether the algorithm was still allowed at the time
+	r := d.Requirements()
+	switch d.Cert.SignatureAlgorithm {
+	case x509.MD2WithRSA, x509.MD5WithRSA:
+		if !r.MD5 {
+			e.Crit("Certificate is signed using %s, which is not allowed since 1 Jul 2012", d.Cert.SignatureAlgorithm)
+		} else {
+			e.Err("Certificate is signed using %s, which is vulnerable to collision attacks", d.Cert.SignatureAlgorithm)
+		}
+	case x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1:
+		switch {
+		case !r.SHA1:
+			e.Err("Certificate is using SHA1, but is issued on/after 1 Jan 2016")
+		case !r.SHA1NotAfter.IsZero() && d.Cert.NotAfter.After(r.SHA1NotAfter):
+			e.Err("Certificate is using SHA1, but is still valid on/after %s", r.SHA1NotAfter.Format("2 Jan 2006"))
+		default:
+			e.Notice("Certificate is using SHA1, which was allowed at issuance")
+		}
 	}
 
-	if d.Cert.NotBefore.After(time.Date(2015, 1, 16, 0, 0, 0, 0, time.UTC)) &&
-		d.Cert.NotAfter.After(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)) {
-		e.Err("Certificate is using SHA1, but is still valid on/after 1 Jan 2017")
-		return e
+	return e
+}
+
+// ecdsaHashStrength contains the security level in bits of the hash used in
+// the ECDSA signature algorithms.
+var ecdsaHashStrength = map[x509.SignatureAlgorithm]int{
+	x509.ECDSAWithSHA1:   80,
+	x509.ECDSAWithSHA256: 128,
+	x509.ECDSAWithSHA384: 192,
+	x509.ECDSAWithSHA512: 256,
+}
+
+// curveStrength contains the security level in bits of the curves, P-521 is
+// at the 256 bit level of SHA-512 and not at half its key size.
+var curveStrength = map[string]int{
+	"P-224": 112,
+	"P-256": 128,
+	"P-384": 192,
+	"P-521": 256,
+}
+
+// signingKey returns the ECDSA key used to sign the certificate if known
+func signingKey(d *certdata.Data) *ecdsa.PublicKey {
+	var key interface{}
+	if d.Issuer != nil {
+		key = d.Issuer.PublicKey
+	} else if bytes.Equal(d.Cert.RawSubject, d.Cert.RawIssuer) {
+		key = d.Cert.PublicKey
 	}
 
+	if k, ok := key.(*ecdsa.PublicKey); ok {
+		return k
+	}
 	return nil
 }
@@ -0,0 +1,276 @@
+package smime
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"strings"
+
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "S/MIME Check"
+
+// Required reports PS certificates without an S/MIME BR policy identifier, it
+// is set by the cabf-smime profile. Certificates with one of the identifiers
+// are always checked.
+var Required bool
+
+// oidSMIMEPolicy is the arc of the S/MIME BR reserved policy identifiers,
+// 2.23.140.1.5.<validation>.<generation>
+var oidSMIMEPolicy = asn1.ObjectIdentifier{2, 23, 140, 1, 5}
+
+var (
+	oidSubjectAltName  = asn1.ObjectIdentifier{2, 5, 29, 17}
+	oidSmtpUTF8Mailbox = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 8, 9}
+	oidEmailAddress    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}
+)
+
+// validations are the certificate profiles of S/MIME BR §7.1.6.1
+var validations = map[int]string{1: "mailbox", 2: "organization", 3: "sponsor", 4: "individual"}
+
+// generations are the profile generations of S/MIME BR §7.1.6.1
+var generations = map[int]string{1: "legacy", 2: "multipurpose", 3: "strict"}
+
+// lifetimes are the maximum validity periods of S/MIME BR §6.3.2
+var lifetimes = map[string]certdata.Lifetime{
+	"legacy":       {Days: 1185, Desc: "1185 days"},
+	"multipurpose": {Days: 825, Desc: "825 days"},
+	"strict":       {Days: 825, Desc: "825 days"},
+}
+
+// subjectAttributes are the subject attributes allowed for mailbox-validated
+// certificates, S/MIME BR §7.1.4.2.3
+var subjectAttributes = map[string]string{
+	"2.5.4.3":              "commonName",
+	"2.5.4.5":              "serialNumber",
+	"1.2.840.113549.1.9.1": "emailAddress",
+}
+
+// personalAttributes name the natural person and are not allowed for
+// organization-validated certificates
+var personalAttributes = map[string]string{
+	"2.5.4.4":  "surname",
+	"2.5.4.42": "givenName",
+	"2.5.4.65": "pseudonym",
+}
+
+var forbiddenEKUs = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:          "AnyExtendedKeyUsage",
+	x509.ExtKeyUsageServerAuth:   "ServerAuth",
+	x509.ExtKeyUsageCodeSigning:  "CodeSigning",
+	x509.ExtKeyUsageTimeStamping: "TimeStamping",
+	x509.ExtKeyUsageOCSPSigning:  "OCSPSigning",
+}
+
+func init() {
+	filter := &checks.Filter{
+		Type: []string{"PS"},
+	}
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/smime",
+		Description: "S/MIME certificates meet the requirements of their validation type and generation",
+		Citation:    "S/MIME BR §6.3.2, §7.1.2.3, §7.1.4.2, §7.1.6.1",
+	}, filter, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate does not contain an S/MIME BR policy identifier":                                               "CERTLINT_SMIME_001",
+		"Certificate contains more than one S/MIME BR policy identifier":                                            "CERTLINT_SMIME_002",
+		"Certificate contains the unknown S/MIME BR policy identifier %s":                                           "CERTLINT_SMIME_003",
+		"Certificate doesn't contain the EmailProtection key usage":                                                 "CERTLINT_SMIME_004",
+		"Certificate contains the %s key usage, which is not allowed for S/MIME certificates":                       "CERTLINT_SMIME_005",
+		"Certificate contains a key usage other than EmailProtection, which is not allowed for strict certificates": "CERTLINT_SMIME_006",
+		"Certificate doesn't contain an rfc822Name or SmtpUTF8Mailbox subjectAltName":                               "CERTLINT_SMIME_007",
+		"Certificate subjectAltName contains a %s, which is not allowed for strict certificates":                    "CERTLINT_SMIME_008",
+		"Subject emailAddress '%s' is not listed in subjectAltName":                                                 "CERTLINT_SMIME_009",
+		"Subject commonName '%s' is not a mailbox address listed in subjectAltName":                                 "CERTLINT_SMIME_010",
+		"Subject attribute %s is not allowed for %s-validated certificates":                                         "CERTLINT_SMIME_011",
+		"Subject organizationName is required for %s-validated certificates":                                        "CERTLINT_SMIME_012",
+		"Certificate LifeTime exceeds %s of %s S/MIME certificates":                                                 "CERTLINT_SMIME_013",
+	})
+}
+
+// Check verifies S/MIME certificates against the profile of their S/MIME BR
+// policy identifier
+//
+// https://cabforum.org/smime-br/
+func Check(d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	validation, generation := profile(d, e)
+	if len(validation) == 0 {
+		if Required {
+			e.Err("Certificate does not contain an S/MIME BR policy identifier")
+		}
+		return e
+	}
+
+	checkExtKeyUsage(d, e, generation)
+
+	mailboxes, ok := checkSubjectAltName(d, e, generation)
+	if ok {
+		checkSubject(d, e, validation, mailboxes)
+	}
+
+	lifetime := lifetimes[generation]
+	if d.Cert.NotAfter.After(lifetime.NotAfter(d.Cert.NotBefore)) {
+		e.Err("Certificate LifeTime exceeds %s of %s S/MIME certificates", lifetime.Desc, generation)
+	}
+
+	return e
+}
+
+// profile returns the validation type and generation of the S/MIME BR policy
+// identifier
+func profile(d *certdata.Data, e *errors.Errors) (validation, generation string) {
+	var found int
+	for _, p := range d.Cert.PolicyIdentifiers {
+		if len(p) != len(oidSMIMEPolicy)+2 || !p[:len(oidSMIMEPolicy)].Equal(oidSMIMEPolicy) {
+			continue
+		}
+		v, vok := validations[p[len(oidSMIMEPolicy)]]
+		g, gok := generations[p[len(oidSMIMEPolicy)+1]]
+		if !vok || !gok {
+			e.Err("Certificate contains the unknown S/MIME BR policy identifier %s", p.String())
+			continue
+		}
+		if found++; found == 1 {
+			validation, generation = v, g
+		}
+	}
+	if found > 1 {
+		e.Err("Certificate contains more than one S/MIME BR policy identifier")
+	}
+	return validation, generation
+}
+
+// checkExtKeyUsage verifies the extended key usages, S/MIME BR §7.1.2.3 (f)
+func checkExtKeyUsage(d *certdata.Data, e *errors.Errors, generation string) {
+	var emailProtection bool
+	for _, ku := range d.Cert.ExtKeyUsage {
+		if ku == x509.ExtKeyUsageEmailProtection {
+			emailProtection = true
+			continue
+		}
+		if name, ok := forbiddenEKUs[ku]; ok {
+			e.Err("Certificate contains the %s key usage, which is not allowed for S/MIME certificates", name)
+		} else if generation == "strict" {
+			e.Err("Certificate contains a key usage other than EmailProtection, which is not allowed for strict certificates")
+		}
+	}
+	if generation == "strict" && len(d.Cert.UnknownExtKeyUsage) > 0 {
+		e.Err("Certificate contains a key usage other than EmailProtection, which is not allowed for strict certificates")
+	}
+	if !emailProtection {
+		e.Err("Certificate doesn't contain the EmailProtection key usage")
+	}
+}
+
+// checkSubjectAltName verifies the subjectAltName contains a mailbox address,
+// S/MIME BR §7.1.2.3 (h), and returns the lower cased mailbox addresses
+func checkSubjectAltName(d *certdata.Data, e *errors.Errors, generation string) (map[string]bool, bool) {
+	mailboxes := make(map[string]bool)
+	for _, m := range d.Cert.EmailAddresses {
+		mailboxes[strings.ToLower(m)] = true
+	}
+
+	for _, ext := range d.Cert.Extensions {
+		if !ext.Id.Equal(oidSubjectAltName) {
+			continue
+		}
+		var names []asn1.RawValue
+		// Parse errors are reported by the SubjectAltName Extension Check
+		if _, err := asn1.Unmarshal(ext.Value, &names); err != nil {
+			return nil, false
+		}
+		for _, n := range names {
+			if n.Class != asn1.ClassContextSpecific || n.Tag != 0 {
+				continue
+			}
+			var on struct {
+				TypeID asn1.ObjectIdentifier
+				Value  string `asn1:"explicit,tag:0,utf8"`
+			}
+			if _, err := asn1.UnmarshalWithParams(n.FullBytes, &on, "tag:0"); err == nil && on.TypeID.Equal(oidSmtpUTF8Mailbox) {
+				mailboxes[strings.ToLower(on.Value)] = true
+			}
+		}
+	}
+
+	if len(mailboxes) == 0 {
+		e.Err("Certificate doesn't contain an rfc822Name or SmtpUTF8Mailbox subjectAltName")
+	}
+
+	if generation == "strict" {
+		if len(d.Cert.DNSNames) > 0 {
+			e.Err("Certificate subjectAltName contains a %s, which is not allowed for strict certificates", "dNSName")
+		}
+		if len(d.Cert.IPAddresses) > 0 {
+			e.Err("Certificate subjectAltName contains a %s, which is not allowed for strict certificates", "iPAddress")
+		}
+		if len(d.Cert.URIs) > 0 {
+			e.Err("Certificate subjectAltName contains a %s, which is not allowed for strict certificates", "uniformResourceIdentifier")
+		}
+	}
+
+	return mailboxes, true
+}
+
+// checkSubject verifies the subject attributes of the validation type,
+// S/MIME BR §7.1.4.2
+func checkSubject(d *certdata.Data, e *errors.Errors, validation string, mailboxes map[string]bool) {
+	for _, n := range d.Cert.Subject.Names {
+		oid := n.Type.String()
+		switch validation {
+		case "mailbox":
+			if _, ok := subjectAttributes[oid]; !ok {
+				e.Err("Subject attribute %s is not allowed for %s-validated certificates", attributeName(oid), validation)
+			}
+		case "organization":
+			if name, ok := personalAttributes[oid]; ok {
+				e.Err("Subject attribute %s is not allowed for %s-validated certificates", name, validation)
+			}
+		case "individual":
+			if oid == "2.5.4.10" {
+				e.Err("Subject attribute %s is not allowed for %s-validated certificates", "organizationName", validation)
+			}
+		}
+
+		if n.Type.Equal(oidEmailAddress) {
+			if s, ok := n.Value.(string); ok && !mailboxes[strings.ToLower(s)] {
+				e.Err("Subject emailAddress '%s' is not listed in subjectAltName", s)
+			}
+		}
+	}
+
+	switch validation {
+	case "organization", "sponsor":
+		if len(d.Cert.Subject.Organization) == 0 {
+			e.Err("Subject organizationName is required for %s-validated certificates", validation)
+		}
+	case "mailbox":
+		if cn := d.Cert.Subject.CommonName; len(cn) > 0 && !mailboxes[strings.ToLower(cn)] {
+			e.Err("Subject commonName '%s' is not a mailbox address listed in subjectAltName", cn)
+		}
+	}
+}
+
+// attributeName returns the name of a subject attribute for the findings
+func attributeName(oid string) string {
+	if name, ok := personalAttributes[oid]; ok {
+		return name
+	}
+	switch oid {
+	case "2.5.4.6":
+		return "countryName"
+	case "2.5.4.7":
+		return "localityName"
+	case "2.5.4.8":
+		return "stateOrProvinceName"
+	case "2.5.4.10":
+		return "organizationName"
+	case "2.5.4.11":
+		return "organizationalUnitName"
+	}
+	return oid
+}
@@ -60,9 +60,12 @@ var (
 	deltaRevocationList         = asn1.ObjectIdentifier{2, 5, 4, 53}
 	attributeCertificate        = asn1.ObjectIdentifier{2, 5, 4, 58}
 	pseudonym                   = asn1.ObjectIdentifier{2, 5, 4, 65}
+	organizationIdentifier      = asn1.ObjectIdentifier{2, 5, 4, 97}
 
 	emailAddress = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}
 
+	domainComponent = asn1.ObjectIdentifier{0, 9, 2342, 19200300, 100, 1, 25}
+
 	jurisdictionLocalityName        = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 60, 2, 1, 1}
 	jurisdictionStateOrProvinceName = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 60, 2, 1, 2}
 	jurisdictionCountryName         = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 60, 2, 1, 3}
@@ -0,0 +1,77 @@
+package subject
+
+import (
+	"math/big"
+	"regexp"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// organizationIdentifierFormat matches the legal person semantics identifier
+// of ETSI EN 319 412-1 §5.1.4, a 3 character identity type reference, a 2
+// character ISO 3166-1 country code, a '-' and the identifier. Nationally
+// defined types use the country code followed by ':' instead.
+var (
+	organizationIdentifierFormat = regexp.MustCompile(`^([A-Z]{3})([A-Z]{2})-(.+)$`)
+	nationalIdentifierFormat     = regexp.MustCompile(`^[A-Z]{2}:.+$`)
+	leiFormat                    = regexp.MustCompile(`^[0-9A-Z]{18}[0-9]{2}$`)
+)
+
+// identityTypes are the identity type references of EN 319 412-1 §5.1.4 and
+// ETSI TS 119 495
+var identityTypes = map[string]string{
+	"VAT": "value added tax identification",
+	"NTR": "national trade register",
+	"PSD": "payment service provider authorization",
+	"LEI": "legal entity identifier",
+}
+
+// checkOrganizationIdentifier verifies the format of an organizationIdentifier
+func checkOrganizationIdentifier(e *errors.Errors, value interface{}) {
+	id, _ := value.(string)
+	if nationalIdentifierFormat.MatchString(id) {
+		return
+	}
+
+	m := organizationIdentifierFormat.FindStringSubmatch(id)
+	if m == nil {
+		e.Err("organizationIdentifier '%s' is not formatted as identity type, country code, '-' and reference", id)
+		return
+	}
+	if _, ok := identityTypes[m[1]]; !ok {
+		e.Err("organizationIdentifier '%s' contains the unknown identity type '%s'", id, m[1])
+		return
+	}
+
+	// A LEI is global, its country code is XG
+	if m[1] == "LEI" {
+		if m[2] != "XG" {
+			e.Err("organizationIdentifier '%s' must use the country code XG for a LEI", id)
+		}
+		if !validLEI(m[3]) {
+			e.Err("organizationIdentifier '%s' contains an invalid LEI", id)
+		}
+	}
+}
+
+// validLEI verifies the format and ISO 7064 MOD 97-10 check digits of a LEI,
+// ISO 17442
+func validLEI(lei string) bool {
+	if !leiFormat.MatchString(lei) {
+		return false
+	}
+
+	// Letters are replaced by two digits, A = 10 to Z = 35
+	var digits []byte
+	for _, c := range []byte(lei) {
+		if c >= 'A' && c <= 'Z' {
+			v := c - 'A' + 10
+			digits = append(digits, '0'+v/10, '0'+v%10)
+		} else {
+			digits = append(digits, c)
+		}
+	}
+
+	n, ok := new(big.Int).SetString(string(digits), 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
@@ -3,6 +3,7 @@ package subject
 import (
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"strings"
 
 	"github.com/weyhmueller/certlint/certdata"
 	"github.com/weyhmueller/certlint/checks"
@@ -15,12 +16,66 @@ func init() {
 	filter := &checks.Filter{
 		Type: []string{"DV", "OV", "IV", "EV"},
 	}
-	checks.RegisterCertificateCheck(checkName, filter, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/subject",
+		Description: "Subject contains the attributes required for the certificate type",
+		Citation:    "BR §7.1.4.2.2",
+	}, filter, Check)
+	errors.RegisterCodes(map[string]string{
+		"Subject only contains domainComponent attributes and no subjectAltName is present":                                          "CERTLINT_SUBJ_001",
+		"organizationName is required for %s certificates":                                                                           "CERTLINT_SUBJ_002",
+		"localityName is required for %s certificates":                                                                               "CERTLINT_SUBJ_003",
+		"businessCategory is required for %s certificates":                                                                           "CERTLINT_SUBJ_004",
+		"jurisdictionCountryName is required for %s certificates":                                                                    "CERTLINT_SUBJ_005",
+		"serialNumber is required for %s certificates":                                                                               "CERTLINT_SUBJ_006",
+		"commonName field is deprecated":                                                                                             "CERTLINT_SUBJ_007",
+		"emailAddress field is deprecated":                                                                                           "CERTLINT_SUBJ_008",
+		"surname may only set in combination with givenName":                                                                         "CERTLINT_SUBJ_009",
+		"localityName or stateOrProvinceName is required if surname is set":                                                          "CERTLINT_SUBJ_010",
+		"countryName MUST contain the two-letter ISO 3166-1 country code":                                                            "CERTLINT_SUBJ_011",
+		"jurisdictionCountryName MUST contain the two-letter ISO 3166-1 country code":                                                "CERTLINT_SUBJ_012",
+		"localityName is not allowed without organizationName or givenName and surname":                                              "CERTLINT_SUBJ_013",
+		"stateOrProvinceName is not allowed without organizationName or givenName and surname":                                       "CERTLINT_SUBJ_014",
+		"streetAddress is not allowed without organizationName or givenName and surname":                                             "CERTLINT_SUBJ_015",
+		"postalCode is not allowed without organizationName or givenName and surname":                                                "CERTLINT_SUBJ_016",
+		"localityName or stateOrProvinceName is required if organizationName is set":                                                 "CERTLINT_SUBJ_017",
+		"stateOrProvinceName is required if organizationName is set":                                                                 "CERTLINT_SUBJ_018",
+		"countryName is required if organizationName is set":                                                                         "CERTLINT_SUBJ_019",
+		"businessCategory should contain 'Private Organization', 'Government Entity', 'Business Entity', or 'Non-Commercial Entity'": "CERTLINT_SUBJ_020",
+		"givenName may only set in combination with surname":                                                                         "CERTLINT_SUBJ_021",
+		"countryName is required for %s certificates":                                                                                "CERTLINT_SUBJ_022",
+		"jurisdictionLocalityName requires jurisdictionStateOrProvinceName":                                                          "CERTLINT_SUBJ_023",
+		"jurisdictionStateOrProvinceName requires jurisdictionCountryName":                                                           "CERTLINT_SUBJ_024",
+		"serialNumber must contain the registration number of the subject":                                                           "CERTLINT_SUBJ_025",
+		"%s must not be present more than once in %s certificates":                                                                   "CERTLINT_SUBJ_026",
+		"organizationIdentifier '%s' is not formatted as identity type, country code, '-' and reference":                             "CERTLINT_SUBJ_027",
+		"organizationIdentifier '%s' contains the unknown identity type '%s'":                                                        "CERTLINT_SUBJ_028",
+		"organizationIdentifier '%s' contains an invalid LEI":                                                                        "CERTLINT_SUBJ_029",
+		"organizationIdentifier '%s' must use the country code XG for a LEI":                                                         "CERTLINT_SUBJ_030",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
 func Check(d *certdata.Data) *errors.Errors {
-	return checkDN(d.Type, d.Cert.Subject.Names)
+	var e = checkDN(d.Type, d.Cert.Subject.Names)
+
+	// Without a commonName or subjectAltName the certificate can't be matched
+	// to a hostname
+	if onlyDomainComponents(d.Cert.Subject.Names) && len(d.Cert.DNSNames) == 0 && len(d.Cert.IPAddresses) == 0 {
+		e.Err("Subject only contains domainComponent attributes and no subjectAltName is present")
+	}
+
+	return e
+}
+
+// onlyDomainComponents returns true if the dn only contains domainComponents
+func onlyDomainComponents(dn []pkix.AttributeTypeAndValue) bool {
+	for _, n := range dn {
+		if !n.Type.Equal(domainComponent) {
+			return false
+		}
+	}
+	return len(dn) > 0
 }
 
 // Subject Distinguished Name Fields
@@ -48,6 +103,34 @@ func checkDN(vetting string, dn []pkix.AttributeTypeAndValue) *errors.Errors {
 		if !inDN(dn, serialNumber) {
 			e.Err("serialNumber is required for %s certificates", vetting)
 		}
+		if !inDN(dn, countryName) {
+			e.Err("countryName is required for %s certificates", vetting)
+		}
+
+		// The jurisdiction of incorporation is given from the country down to
+		// the locality, EVG §9.2.4
+		if inDN(dn, jurisdictionLocalityName) && !inDN(dn, jurisdictionStateOrProvinceName) {
+			e.Err("jurisdictionLocalityName requires jurisdictionStateOrProvinceName")
+		}
+		if inDN(dn, jurisdictionStateOrProvinceName) && !inDN(dn, jurisdictionCountryName) {
+			e.Err("jurisdictionStateOrProvinceName requires jurisdictionCountryName")
+		}
+
+		for _, attr := range []struct {
+			name string
+			oid  asn1.ObjectIdentifier
+		}{
+			{"organizationName", organizationName},
+			{"businessCategory", businessCategory},
+			{"serialNumber", serialNumber},
+			{"jurisdictionLocalityName", jurisdictionLocalityName},
+			{"jurisdictionStateOrProvinceName", jurisdictionStateOrProvinceName},
+			{"jurisdictionCountryName", jurisdictionCountryName},
+		} {
+			if countDN(dn, attr.oid) > 1 {
+				e.Err("%s must not be present more than once in %s certificates", attr.name, vetting)
+			}
+		}
 	}
 
 	// Field related requirements
@@ -57,8 +140,8 @@ func checkDN(vetting string, dn []pkix.AttributeTypeAndValue) *errors.Errors {
 		// commonName
 		// If present, this field MUST contain a single IP address or Fully‐Qualified Domain Name
 		case n.Type.Equal(commonName):
-			// report deprecated common name field as info until not commonly used/accepted
-			e.Info("commonName field is deprecated")
+			// The field is NOT RECOMMENDED, browsers only use the subjectAltName
+			e.Notice("commonName field is deprecated")
 
 		case n.Type.Equal(emailAddress):
 			// report deprecated email address field as info until not commonly used/accepted
@@ -142,8 +225,17 @@ func checkDN(vetting string, dn []pkix.AttributeTypeAndValue) *errors.Errors {
 				e.Err("businessCategory should contain 'Private Organization', 'Government Entity', 'Business Entity', or 'Non-Commercial Entity'")
 			}
 
+		// organizationIdentifier
+		case n.Type.Equal(organizationIdentifier):
+			checkOrganizationIdentifier(e, n.Value)
+
 		// serialNumber
+		// The registration number, or the date of incorporation of entities
+		// without one, EVG §9.2.5
 		case n.Type.Equal(serialNumber):
+			if sn, ok := n.Value.(string); vetting == "EV" && (!ok || len(strings.TrimSpace(sn)) == 0) {
+				e.Err("serialNumber must contain the registration number of the subject")
+			}
 
 		// givenName
 		case n.Type.Equal(givenName):
@@ -157,6 +249,16 @@ func checkDN(vetting string, dn []pkix.AttributeTypeAndValue) *errors.Errors {
 	return e
 }
 
+func countDN(dn []pkix.AttributeTypeAndValue, attr asn1.ObjectIdentifier) int {
+	var c int
+	for _, n := range dn {
+		if n.Type.Equal(attr) {
+			c++
+		}
+	}
+	return c
+}
+
 func inDN(dn []pkix.AttributeTypeAndValue, attr asn1.ObjectIdentifier) bool {
 	for _, n := range dn {
 		if n.Type.Equal(attr) {
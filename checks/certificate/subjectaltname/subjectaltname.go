@@ -1,6 +1,7 @@
 package subjectaltname
 
 import (
+	"net"
 	"strings"
 
 	"github.com/weyhmueller/certlint/certdata"
@@ -11,9 +12,27 @@ import (
 const checkName = "Subject Alternative Names Check"
 
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/subjectaltname",
+		Description: "Certificate contains valid subjectAltNames that include the common name",
+		Citation:    "RFC 5280 §4.2.1.6, BR §7.1.4.2.1",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate doesn't contain any subjectAltName":                                                   "CERTLINT_SAN_001",
+		"Certificate subjectAltName '%s' contains a whitespace":                                            "CERTLINT_SAN_002",
+		"Certificate subjectAltName '%s' contains uppercase characters, should be normalized to lowercase": "CERTLINT_SAN_003",
+		"Certificate CN is not listed in subjectAltName":                                                   "CERTLINT_SAN_004",
+		"Certificate CN '%s' differs in case from the subjectAltName":                                      "CERTLINT_SAN_005",
+	})
 }
 
+// Results of matching the commonName with the subjectAltName
+const (
+	noMatch = iota
+	caseMatch
+	exactMatch
+)
+
 // Check performs a strict verification on the extension according to the standard(s)
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
@@ -38,29 +57,48 @@ func Check(d *certdata.Data) *errors.Errors {
 			return e
 		}
 
-		var cnInSan bool
 		for _, s := range d.Cert.DNSNames {
-			if strings.EqualFold(d.Cert.Subject.CommonName, s) {
-				cnInSan = true
-			}
 			if strings.Contains(s, " ") {
 				e.Err("Certificate subjectAltName '%s' contains a whitespace", s)
 			}
-		}
-
-		// Maybe it's an IP address
-		if !cnInSan {
-			for _, s := range d.Cert.IPAddresses {
-				if strings.EqualFold(d.Cert.Subject.CommonName, s.String()) {
-					cnInSan = true
-				}
+			if strings.ToLower(s) != s {
+				e.Notice("Certificate subjectAltName '%s' contains uppercase characters, should be normalized to lowercase", s)
 			}
 		}
 
-		if !cnInSan {
-			e.Err("Certificate CN is not listed in subjectAltName")
+		// If present the commonName must be a copy of a dNSName or iPAddress
+		if cn := d.Cert.Subject.CommonName; len(cn) > 0 {
+			switch cnInSAN(d, cn) {
+			case noMatch:
+				e.Err("Certificate CN is not listed in subjectAltName")
+			case caseMatch:
+				e.Err("Certificate CN '%s' differs in case from the subjectAltName", cn)
+			}
 		}
 	}
 
 	return e
 }
+
+// cnInSAN returns whether the commonName is a byte-for-byte copy of a dNSName,
+// only matches it ignoring case, or is the textual form of an iPAddress,
+// BR §7.1.4.3
+func cnInSAN(d *certdata.Data, cn string) int {
+	match := noMatch
+	for _, s := range d.Cert.DNSNames {
+		if s == cn {
+			return exactMatch
+		}
+		if strings.EqualFold(s, cn) {
+			match = caseMatch
+		}
+	}
+	if ip := net.ParseIP(cn); ip != nil {
+		for _, s := range d.Cert.IPAddresses {
+			if s.Equal(ip) {
+				return exactMatch
+			}
+		}
+	}
+	return match
+}
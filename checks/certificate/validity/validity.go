@@ -1,41 +1,92 @@
 package validity
 
 import (
+	"bytes"
 	"time"
 
 	"github.com/weyhmueller/certlint/certdata"
 	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/ctlogs"
 	"github.com/weyhmueller/certlint/errors"
 )
 
 const checkName = "Validity Check"
 
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/validity",
+		Description: "Validity period does not exceed the maximum lifetime in effect at issuance",
+		Citation:    "RFC 5280 §4.1.2.5, BR §6.3.2",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"CA certificate NotBefore is before the NotBefore of the issuing CA":                "CERTLINT_VAL_001",
+		"CA certificate NotAfter extends beyond the NotAfter of the issuing CA":             "CERTLINT_VAL_002",
+		"Certificate NotAfter is more than 5 years in the future":                           "CERTLINT_VAL_003",
+		"EV Certificate LifeTime exceeds %s":                                                "CERTLINT_VAL_004",
+		"Certificate LifeTime exceeds %s":                                                   "CERTLINT_VAL_005",
+		"Certificate NotBefore is %.0f hours before the earliest SCT, exceeding %.0f hours": "CERTLINT_VAL_006",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
 func Check(d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 
+	// An intermediate CA certificate must be within the validity of its issuer
+	if d.Issuer != nil && d.Cert.IsCA && !bytes.Equal(d.Cert.RawSubject, d.Cert.RawIssuer) {
+		if d.Cert.NotBefore.Before(d.Issuer.NotBefore) {
+			e.Err("CA certificate NotBefore is before the NotBefore of the issuing CA")
+		}
+		if d.Cert.NotAfter.After(d.Issuer.NotAfter) {
+			e.Err("CA certificate NotAfter extends beyond the NotAfter of the issuing CA")
+		}
+	}
+
+	// Sanity backstop for leaf certificates with an absurd NotAfter, regardless
+	// of the lifetime calculated below
+	if !d.Cert.IsCA && d.Cert.NotAfter.After(time.Now().AddDate(5, 0, 0)) {
+		switch d.Type {
+		case "DV", "OV", "IV", "EV":
+			e.Err("Certificate NotAfter is more than 5 years in the future")
+		}
+	}
+
+	// The maximum lifetime depends on the rules in effect at issuance
+	r := d.Requirements()
 	switch d.Type {
 	case "EV":
-		if d.Cert.NotBefore.After(d.Cert.NotBefore.AddDate(0, 27, 0)) {
-			e.Err("EV Certificate LifeTime exceeds 27 months")
-			return e
+		if d.Cert.NotAfter.After(r.EVLifetime.NotAfter(d.Cert.NotBefore)) {
+			e.Err("EV Certificate LifeTime exceeds %s", r.EVLifetime.Desc)
+		}
+	case "DV", "OV", "IV":
+		if d.Cert.NotAfter.After(r.Lifetime.NotAfter(d.Cert.NotBefore)) {
+			e.Err("Certificate LifeTime exceeds %s", r.Lifetime.Desc)
 		}
-	case "DV", "OV":
-		if d.Cert.NotBefore.After(time.Date(2015, 4, 1, 0, 0, 0, 0, time.UTC)) {
-			if d.Cert.NotBefore.After(d.Cert.NotBefore.AddDate(0, 39, 0)) {
-				e.Err("Certificate LifeTime exceeds 39 months")
-				return e
-			}
-		} else {
-			if d.Cert.NotBefore.After(d.Cert.NotBefore.AddDate(0, 60, 0)) {
-				e.Err("Certificate LifeTime exceeds 60 months")
-				return e
-			}
+	}
+
+	// The embedded SCTs are the best evidence of the moment of issuance, the
+	// NotBefore may only be backdated a limited time
+	if r.MaxBackdate > 0 && !d.Cert.IsCA {
+		if issued, ok := earliestSCT(d); ok && issued.Sub(d.Cert.NotBefore) > r.MaxBackdate {
+			e.Err("Certificate NotBefore is %.0f hours before the earliest SCT, exceeding %.0f hours", issued.Sub(d.Cert.NotBefore).Hours(), r.MaxBackdate.Hours())
 		}
 	}
 	return e
 }
+
+// earliestSCT returns the time of the earliest embedded SCT, parse errors are
+// reported by the SCT checks
+func earliestSCT(d *certdata.Data) (time.Time, bool) {
+	scts, err := ctlogs.EmbeddedSCTs(d.Cert)
+	if err != nil || len(scts) == 0 {
+		return time.Time{}, false
+	}
+
+	t := scts[0].Time()
+	for _, s := range scts[1:] {
+		if s.Time().Before(t) {
+			t = s.Time()
+		}
+	}
+	return t, true
+}
@@ -9,7 +9,14 @@ import (
 const checkName = "Certificate Version Check"
 
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/version",
+		Description: "Certificate is a version 3 certificate",
+		Citation:    "RFC 5280 §4.1.2.1, BR §7.1.1",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate is not V3 (%d)": "CERTLINT_VER_001",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
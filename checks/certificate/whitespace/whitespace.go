@@ -0,0 +1,78 @@
+package whitespace
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "Whitespace and Control Character Check"
+
+// attributeNames contains the short names of the most common subject attributes
+var attributeNames = map[string]string{
+	asn1.ObjectIdentifier{2, 5, 4, 3}.String():  "commonName",
+	asn1.ObjectIdentifier{2, 5, 4, 5}.String():  "serialNumber",
+	asn1.ObjectIdentifier{2, 5, 4, 6}.String():  "countryName",
+	asn1.ObjectIdentifier{2, 5, 4, 7}.String():  "localityName",
+	asn1.ObjectIdentifier{2, 5, 4, 8}.String():  "stateOrProvinceName",
+	asn1.ObjectIdentifier{2, 5, 4, 9}.String():  "streetAddress",
+	asn1.ObjectIdentifier{2, 5, 4, 10}.String(): "organizationName",
+	asn1.ObjectIdentifier{2, 5, 4, 11}.String(): "organizationalUnitName",
+	asn1.ObjectIdentifier{2, 5, 4, 17}.String(): "postalCode",
+}
+
+func init() {
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/whitespace",
+		Description: "Subject and subjectAltName contain no surrounding whitespace or control characters",
+		Citation:    "BR §7.1.4.2",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"%s contains leading or trailing whitespace": "CERTLINT_WS_001",
+		"%s contains an embedded NUL character":      "CERTLINT_WS_002",
+		"%s contains a control character":            "CERTLINT_WS_003",
+	})
+}
+
+// Check verifies that the subject and subjectAltName values do not contain
+// leading or trailing whitespace or control characters, which could be used
+// for display spoofing.
+func Check(d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	for _, n := range d.Cert.Subject.Names {
+		if s, ok := n.Value.(string); ok {
+			name, ok := attributeNames[n.Type.String()]
+			if !ok {
+				name = n.Type.String()
+			}
+			checkString(e, fmt.Sprintf("Subject %s", name), s)
+		}
+	}
+	for _, s := range d.Cert.DNSNames {
+		checkString(e, "subjectAltName dNSName", s)
+	}
+	for _, s := range d.Cert.EmailAddresses {
+		checkString(e, "subjectAltName rfc822Name", s)
+	}
+
+	return e
+}
+
+func checkString(e *errors.Errors, field, s string) {
+	if strings.TrimSpace(s) != s {
+		e.Warning("%s contains leading or trailing whitespace", field)
+	}
+
+	switch {
+	case strings.ContainsRune(s, 0):
+		e.Err("%s contains an embedded NUL character", field)
+	case strings.IndexFunc(s, unicode.IsControl) >= 0:
+		e.Warning("%s contains a control character", field)
+	}
+}
@@ -6,12 +6,27 @@ import (
 	"github.com/weyhmueller/certlint/certdata"
 	"github.com/weyhmueller/certlint/checks"
 	"github.com/weyhmueller/certlint/errors"
+
+	psl "golang.org/x/net/publicsuffix"
 )
 
 const checkName = "Wildcard(s) Check"
 
 func init() {
-	checks.RegisterCertificateCheck(checkName, nil, Check)
+	checks.RegisterCertificateCheck(checkName, checks.Metadata{
+		ID:          "certificate/wildcard",
+		Description: "Wildcards are only used as the complete left most label and not directly under a public suffix",
+		Citation:    "BR §3.2.2.6, RFC 6125 §6.4.3",
+	}, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate should not contain a wildcard":                             "CERTLINT_WC_001",
+		"Certificate subjectAltName '%s' should not contain a wildcard":         "CERTLINT_WC_002",
+		"Certificate wildcard is only allowed as prefix":                        "CERTLINT_WC_003",
+		"Certificate subjectAltName '%s' wildcard is only allowed as prefix":    "CERTLINT_WC_004",
+		"Certificate %s '%s' contains more than one wildcard":                   "CERTLINT_WC_005",
+		"Certificate %s '%s' wildcard is not the complete left most label":      "CERTLINT_WC_006",
+		"Certificate %s '%s' wildcard is directly under the public suffix '%s'": "CERTLINT_WC_007",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
@@ -28,12 +43,12 @@ func Check(d *certdata.Data) *errors.Errors {
 				e.Err("Certificate subjectAltName '%s' should not contain a wildcard", n)
 			}
 		}
-	case "DV", "OV":
-		if strings.LastIndex(d.Cert.Subject.CommonName, "*") > 0 {
+	case "DV", "OV", "IV":
+		if placement(e, "commonName", d.Cert.Subject.CommonName) {
 			e.Err("Certificate wildcard is only allowed as prefix")
 		}
 		for _, n := range d.Cert.DNSNames {
-			if strings.LastIndex(n, "*") > 0 {
+			if placement(e, "subjectAltName", n) {
 				e.Err("Certificate subjectAltName '%s' wildcard is only allowed as prefix", n)
 			}
 		}
@@ -41,3 +56,34 @@ func Check(d *certdata.Data) *errors.Errors {
 
 	return e
 }
+
+// placement verifies a wildcard is the complete left most label of the name
+// and not directly under a public suffix like *.co.uk, it returns true if the
+// wildcard is in another label.
+func placement(e *errors.Errors, field, n string) bool {
+	if !strings.Contains(n, "*") {
+		return false
+	}
+	if strings.Count(n, "*") > 1 {
+		e.Err("Certificate %s '%s' contains more than one wildcard", field, n)
+		return false
+	}
+
+	labels := strings.Split(strings.TrimSuffix(strings.ToLower(n), "."), ".")
+	if !strings.Contains(labels[0], "*") {
+		return true
+	}
+	if labels[0] != "*" {
+		e.Err("Certificate %s '%s' wildcard is not the complete left most label", field, n)
+		return false
+	}
+
+	// Both ICANN and private suffixes are registry controlled, BR §3.2.2.6
+	if len(labels) > 1 {
+		parent := strings.Join(labels[1:], ".")
+		if suffix, _ := psl.PublicSuffix(parent); suffix == parent {
+			e.Err("Certificate %s '%s' wildcard is directly under the public suffix '%s'", field, n, suffix)
+		}
+	}
+	return false
+}
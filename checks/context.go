@@ -0,0 +1,21 @@
+package checks
+
+import (
+	"context"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+func init() {
+	errors.RegisterCodes(map[string]string{
+		"Checks aborted: %s": "CERTLINT_CHECKS_001",
+	})
+}
+
+// aborted returns the error reported when the remaining checks are skipped
+// because the context is done.
+func aborted(ctx context.Context) *errors.Errors {
+	var e = errors.New(nil)
+	e.Err("Checks aborted: %s", ctx.Err())
+	return e
+}
@@ -0,0 +1,57 @@
+package checks
+
+import (
+	"crypto/x509"
+	"sync"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+var csrMutex = &sync.Mutex{}
+
+type csr []csrCheck
+
+type csrCheck struct {
+	name string
+	f    func(*x509.CertificateRequest) *errors.Errors
+}
+
+// CSR contains all imported certificate signing request checks
+var CSR csr
+
+// RegisterCSRCheck adds a new check to CSR
+func RegisterCSRCheck(name string, meta Metadata, f func(*x509.CertificateRequest) *errors.Errors) {
+	registerMetadata(name, meta)
+	csrMutex.Lock()
+	CSR = append(CSR, csrCheck{name, f})
+	csrMutex.Unlock()
+}
+
+// Check runs all the registered certificate signing request checks
+func (c csr) Check(r *x509.CertificateRequest) *errors.Errors {
+	var e = errors.New(nil)
+
+	for _, cc := range c {
+		if disabled[cc.name] {
+			continue
+		}
+		res := cc.f(r)
+		res.SetCheck(cc.name)
+		e.Append(res)
+	}
+
+	return e
+}
+
+// Names returns the names of all registered certificate signing request checks
+// that are not disabled by Select
+func (c csr) Names() []string {
+	var names []string
+	for _, cc := range c {
+		if disabled[cc.name] {
+			continue
+		}
+		names = append(names, cc.name)
+	}
+	return names
+}
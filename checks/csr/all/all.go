@@ -0,0 +1,10 @@
+package all
+
+import (
+	// Import all default checks
+	_ "github.com/weyhmueller/certlint/checks/csr/attributes"
+	_ "github.com/weyhmueller/certlint/checks/csr/publickey"
+	_ "github.com/weyhmueller/certlint/checks/csr/signature"
+	_ "github.com/weyhmueller/certlint/checks/csr/subject"
+	_ "github.com/weyhmueller/certlint/checks/csr/subjectaltname"
+)
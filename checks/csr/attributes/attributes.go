@@ -0,0 +1,112 @@
+package attributes
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "CSR Attributes Check"
+
+var (
+	challengePassword = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 7}
+	extensionRequest  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 14}
+	basicConstraints  = asn1.ObjectIdentifier{2, 5, 29, 19}
+	nameConstraints   = asn1.ObjectIdentifier{2, 5, 29, 30}
+	keyUsage          = asn1.ObjectIdentifier{2, 5, 29, 15}
+)
+
+func init() {
+	checks.RegisterCSRCheck(checkName, checks.Metadata{
+		ID:          "csr/attributes",
+		Description: "Attributes and requested extensions are valid and not duplicated",
+		Citation:    "RFC 2986 §4.1, RFC 2985 §5.4",
+	}, Check)
+	errors.RegisterCodes(map[string]string{
+		"Failed to parse CSR attributes: %s":                                                                   "CERTLINT_CSR_ATTR_001",
+		"CSR version is %d, only version 1 is defined":                                                         "CERTLINT_CSR_ATTR_002",
+		"CSR contains attribute %s more than once":                                                             "CERTLINT_CSR_ATTR_003",
+		"CSR contains a challengePassword attribute, which is not used for issuance and could expose a secret": "CERTLINT_CSR_ATTR_004",
+		"CSR contains attribute %s, which is ignored for issuance":                                             "CERTLINT_CSR_ATTR_005",
+		"CSR requests extension %s more than once":                                                             "CERTLINT_CSR_ATTR_006",
+		"CSR requests a CA certificate in the basicConstraints extension":                                      "CERTLINT_CSR_ATTR_007",
+		"CSR requests the nameConstraints extension, which is only allowed in CA certificates":                 "CERTLINT_CSR_ATTR_008",
+		"CSR requests the keyCertSign or cRLSign key usage":                                                    "CERTLINT_CSR_ATTR_009",
+	})
+}
+
+// RFC 2986 §4.1
+type certificationRequestInfo struct {
+	Version    int
+	Subject    asn1.RawValue
+	PublicKey  asn1.RawValue
+	Attributes []attribute `asn1:"tag:0"`
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+// Check verifies the version, attributes and requested extensions of a
+// certificate signing request
+func Check(r *x509.CertificateRequest) *errors.Errors {
+	var e = errors.New(nil)
+
+	var info certificationRequestInfo
+	if _, err := asn1.Unmarshal(r.RawTBSCertificateRequest, &info); err != nil {
+		e.Err("Failed to parse CSR attributes: %s", err.Error())
+		return e
+	}
+
+	if info.Version != 0 {
+		e.Err("CSR version is %d, only version 1 is defined", info.Version+1)
+	}
+
+	seen := make(map[string]bool)
+	for _, a := range info.Attributes {
+		if seen[a.Type.String()] {
+			e.Err("CSR contains attribute %s more than once", a.Type)
+		}
+		seen[a.Type.String()] = true
+
+		switch {
+		case a.Type.Equal(extensionRequest):
+		case a.Type.Equal(challengePassword):
+			e.Warning("CSR contains a challengePassword attribute, which is not used for issuance and could expose a secret")
+		default:
+			e.Notice("CSR contains attribute %s, which is ignored for issuance", a.Type)
+		}
+	}
+
+	// Subscribers must not be able to request CA capabilities
+	seen = make(map[string]bool)
+	for _, ext := range r.Extensions {
+		if seen[ext.Id.String()] {
+			e.Err("CSR requests extension %s more than once", ext.Id)
+		}
+		seen[ext.Id.String()] = true
+
+		switch {
+		case ext.Id.Equal(basicConstraints):
+			var bc struct {
+				IsCA bool `asn1:"optional"`
+			}
+			if _, err := asn1.Unmarshal(ext.Value, &bc); err == nil && bc.IsCA {
+				e.Err("CSR requests a CA certificate in the basicConstraints extension")
+			}
+		case ext.Id.Equal(nameConstraints):
+			e.Err("CSR requests the nameConstraints extension, which is only allowed in CA certificates")
+		case ext.Id.Equal(keyUsage):
+			// keyCertSign is bit 5 and cRLSign is bit 6
+			var ku asn1.BitString
+			if _, err := asn1.Unmarshal(ext.Value, &ku); err == nil && (ku.At(5) == 1 || ku.At(6) == 1) {
+				e.Err("CSR requests the keyCertSign or cRLSign key usage")
+			}
+		}
+	}
+
+	return e
+}
@@ -0,0 +1,36 @@
+package publickey
+
+import (
+	"crypto/x509"
+	"strings"
+
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/checks/certificate/publickey/goodkey"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "CSR Public Key Check"
+
+func init() {
+	checks.RegisterCSRCheck(checkName, checks.Metadata{
+		ID:          "csr/publickey",
+		Description: "Requested public key has an allowed algorithm and size",
+		Citation:    "BR §6.1.5, BR §6.1.6",
+	}, Check)
+	errors.RegisterCodes(map[string]string{
+		"CSR %s": "CERTLINT_CSR_KEY_001",
+	})
+}
+
+// Check verifies that the requested public key would be accepted in a
+// certificate
+func Check(r *x509.CertificateRequest) *errors.Errors {
+	var e = errors.New(nil)
+
+	gkp := goodkey.NewKeyPolicy()
+	if err := gkp.GoodKey(r.PublicKey); err != nil {
+		e.Err("CSR %s", strings.ToLower(err.Error()))
+	}
+
+	return e
+}
@@ -0,0 +1,44 @@
+package signature
+
+import (
+	"crypto/x509"
+
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "CSR Signature Check"
+
+func init() {
+	checks.RegisterCSRCheck(checkName, checks.Metadata{
+		ID:          "csr/signature",
+		Description: "Signature is valid and uses a secure algorithm",
+		Citation:    "RFC 2986 §4.2",
+	}, Check)
+	errors.RegisterCodes(map[string]string{
+		"CSR is signed using the insecure %s signature algorithm":  "CERTLINT_CSR_SIG_001",
+		"CSR is signed using an unknown signature algorithm":       "CERTLINT_CSR_SIG_002",
+		"CSR signature is invalid, proof of possession failed: %s": "CERTLINT_CSR_SIG_003",
+	})
+}
+
+// Check verifies the proof of possession of the private key
+func Check(r *x509.CertificateRequest) *errors.Errors {
+	var e = errors.New(nil)
+
+	switch r.SignatureAlgorithm {
+	case x509.MD2WithRSA, x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1:
+		// The signature can't be verified as the algorithm is considered insecure
+		e.Err("CSR is signed using the insecure %s signature algorithm", r.SignatureAlgorithm)
+		return e
+	case x509.UnknownSignatureAlgorithm:
+		e.Err("CSR is signed using an unknown signature algorithm")
+		return e
+	}
+
+	if err := r.CheckSignature(); err != nil {
+		e.Err("CSR signature is invalid, proof of possession failed: %s", err.Error())
+	}
+
+	return e
+}
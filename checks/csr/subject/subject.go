@@ -0,0 +1,79 @@
+package subject
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "CSR Subject Check"
+
+var countryName = asn1.ObjectIdentifier{2, 5, 4, 6}
+
+func init() {
+	checks.RegisterCSRCheck(checkName, checks.Metadata{
+		ID:          "csr/subject",
+		Description: "Requested subject is correctly encoded",
+		Citation:    "RFC 5280 §4.1.2.6, BR §7.1.4.2.2",
+	}, Check)
+	errors.RegisterCodes(map[string]string{
+		"Failed to parse CSR subject: %s":                                                                      "CERTLINT_CSR_SUBJ_001",
+		"CSR subject contains a multi-valued RDN":                                                              "CERTLINT_CSR_SUBJ_002",
+		"CSR subject attribute %s is empty":                                                                    "CERTLINT_CSR_SUBJ_003",
+		"CSR subject countryName must be a two letter PrintableString":                                         "CERTLINT_CSR_SUBJ_004",
+		"CSR subject attribute %s uses a deprecated string type, PrintableString or UTF8String should be used": "CERTLINT_CSR_SUBJ_005",
+		"CSR subject attribute %s is not encoded as a DirectoryString":                                         "CERTLINT_CSR_SUBJ_006",
+	})
+}
+
+type attributeTypeAndValue struct {
+	Type  asn1.ObjectIdentifier
+	Value asn1.RawValue
+}
+
+type relativeDistinguishedNameSET []attributeTypeAndValue
+
+// Check verifies the encoding of the requested subject attributes, the raw
+// subject is parsed as the x509 parser does not preserve the string types.
+func Check(r *x509.CertificateRequest) *errors.Errors {
+	var e = errors.New(nil)
+
+	var rdns []relativeDistinguishedNameSET
+	if _, err := asn1.Unmarshal(r.RawSubject, &rdns); err != nil {
+		e.Err("Failed to parse CSR subject: %s", err.Error())
+		return e
+	}
+
+	for _, rdn := range rdns {
+		if len(rdn) > 1 {
+			e.Warning("CSR subject contains a multi-valued RDN")
+		}
+
+		for _, atv := range rdn {
+			if len(atv.Value.Bytes) == 0 {
+				e.Err("CSR subject attribute %s is empty", atv.Type)
+				continue
+			}
+
+			// countryName is a two letter PrintableString
+			if atv.Type.Equal(countryName) {
+				if atv.Value.Tag != asn1.TagPrintableString || len(atv.Value.Bytes) != 2 {
+					e.Err("CSR subject countryName must be a two letter PrintableString")
+				}
+				continue
+			}
+
+			switch atv.Value.Tag {
+			case asn1.TagPrintableString, asn1.TagUTF8String:
+			case asn1.TagT61String, asn1.TagBMPString, 28: // UniversalString
+				e.Warning("CSR subject attribute %s uses a deprecated string type, PrintableString or UTF8String should be used", atv.Type)
+			default:
+				e.Err("CSR subject attribute %s is not encoded as a DirectoryString", atv.Type)
+			}
+		}
+	}
+
+	return e
+}
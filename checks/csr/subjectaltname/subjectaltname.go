@@ -0,0 +1,101 @@
+package subjectaltname
+
+import (
+	"crypto/x509"
+	"strings"
+
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "CSR Subject Alternative Names Check"
+
+func init() {
+	checks.RegisterCSRCheck(checkName, checks.Metadata{
+		ID:          "csr/subjectaltname",
+		Description: "Requested names are fully qualified and public",
+		Citation:    "RFC 5280 §4.2.1.6, BR §7.1.4.2.1",
+	}, Check)
+	errors.RegisterCodes(map[string]string{
+		"CSR contains no subjectAltName or commonName":                              "CERTLINT_CSR_SAN_001",
+		"CSR requests no subjectAltName, only the commonName '%s' can be used":      "CERTLINT_CSR_SAN_002",
+		"CSR requests the private or reserved IP address %s":                        "CERTLINT_CSR_SAN_003",
+		"CSR commonName '%s' is not requested in the subjectAltName":                "CERTLINT_CSR_SAN_004",
+		"CSR requested name '%s' contains uppercase characters":                     "CERTLINT_CSR_SAN_005",
+		"CSR requested name '%s' is not a fully qualified domain name":              "CERTLINT_CSR_SAN_006",
+		"CSR requested name '%s' contains an empty or too long label":               "CERTLINT_CSR_SAN_007",
+		"CSR requested name '%s' contains the invalid character '%c'":               "CERTLINT_CSR_SAN_008",
+		"CSR requested name '%s' contains a label starting or ending with a hyphen": "CERTLINT_CSR_SAN_009",
+	})
+}
+
+// Check verifies the names that are requested in a certificate signing request
+func Check(r *x509.CertificateRequest) *errors.Errors {
+	var e = errors.New(nil)
+
+	if len(r.DNSNames) == 0 && len(r.IPAddresses) == 0 && len(r.EmailAddresses) == 0 {
+		if len(r.Subject.CommonName) == 0 {
+			e.Err("CSR contains no subjectAltName or commonName")
+			return e
+		}
+		e.Warning("CSR requests no subjectAltName, only the commonName '%s' can be used", r.Subject.CommonName)
+		return e
+	}
+
+	cnInSan := len(r.Subject.CommonName) == 0
+	for _, n := range r.DNSNames {
+		if strings.EqualFold(r.Subject.CommonName, n) {
+			cnInSan = true
+		}
+		checkDNSName(e, n)
+	}
+
+	for _, ip := range r.IPAddresses {
+		if r.Subject.CommonName == ip.String() {
+			cnInSan = true
+		}
+		if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+			e.Err("CSR requests the private or reserved IP address %s", ip)
+		}
+	}
+
+	if !cnInSan {
+		e.Warning("CSR commonName '%s' is not requested in the subjectAltName", r.Subject.CommonName)
+	}
+
+	return e
+}
+
+// checkDNSName verifies that n is a syntactically valid fully qualified domain
+// name, a wildcard is only allowed as the complete left most label.
+func checkDNSName(e *errors.Errors, n string) {
+	if strings.ToLower(n) != n {
+		e.Notice("CSR requested name '%s' contains uppercase characters", n)
+	}
+
+	labels := strings.Split(n, ".")
+	if len(labels) < 2 {
+		e.Err("CSR requested name '%s' is not a fully qualified domain name", n)
+		return
+	}
+
+	for i, l := range labels {
+		if i == 0 && l == "*" {
+			continue
+		}
+		if len(l) == 0 || len(l) > 63 {
+			e.Err("CSR requested name '%s' contains an empty or too long label", n)
+			return
+		}
+		for _, c := range l {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				e.Err("CSR requested name '%s' contains the invalid character '%c'", n, c)
+				return
+			}
+		}
+		if l[0] == '-' || l[len(l)-1] == '-' {
+			e.Err("CSR requested name '%s' contains a label starting or ending with a hyphen", n)
+			return
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package checks
 
 import (
+	"context"
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"strings"
@@ -18,32 +19,66 @@ type extensionCheck struct {
 	name   string
 	oid    asn1.ObjectIdentifier
 	filter *Filter
-	f      func(pkix.Extension, *certdata.Data) *errors.Errors
+	f      func(context.Context, pkix.Extension, *certdata.Data) *errors.Errors
 }
 
 // Extensions contains all imported extension checks
 var Extensions extensions
 
+// ConcurrentExtensions enables checking the extensions of a certificate
+// concurrently in CheckAll.
+var ConcurrentExtensions bool
+
 // RegisterExtensionCheck adds a new check to Extensions
-func RegisterExtensionCheck(name string, oid asn1.ObjectIdentifier, filter *Filter, f func(pkix.Extension, *certdata.Data) *errors.Errors) {
+func RegisterExtensionCheck(name string, meta Metadata, oid asn1.ObjectIdentifier, filter *Filter, f func(pkix.Extension, *certdata.Data) *errors.Errors) {
+	RegisterExtensionCheckContext(name, meta, oid, filter, func(_ context.Context, ext pkix.Extension, d *certdata.Data) *errors.Errors {
+		return f(ext, d)
+	})
+}
+
+// RegisterExtensionCheckContext adds a new check to Extensions that receives
+// the context of CheckContext, for checks that need to honor cancellation.
+func RegisterExtensionCheckContext(name string, meta Metadata, oid asn1.ObjectIdentifier, filter *Filter, f func(context.Context, pkix.Extension, *certdata.Data) *errors.Errors) {
+	registerMetadata(name, meta)
 	extMutex.Lock()
 	Extensions = append(Extensions, extensionCheck{name, oid, filter, f})
 	extMutex.Unlock()
 }
 
+func init() {
+	errors.RegisterCodes(map[string]string{
+		"Certificate contains unknown extension (%s)": "CERTLINT_EXT_001",
+	})
+}
+
 // Check lookups the registered extension checks and runs all checks with the
 // same Object Identifier.
 func (ex extensions) Check(ext pkix.Extension, d *certdata.Data) *errors.Errors {
+	return ex.CheckContext(context.Background(), ext, d)
+}
+
+// CheckContext runs all registered extension checks with the same Object
+// Identifier, the remaining checks are skipped when the context is done.
+func (ex extensions) CheckContext(ctx context.Context, ext pkix.Extension, d *certdata.Data) *errors.Errors {
 	var e = errors.New(nil)
 	var found bool
 
 	for _, ec := range ex {
 		if ec.oid.Equal(ext.Id) {
 			found = true
-			if ec.filter != nil && ec.filter.Check(d) {
+			if disabled[ec.name] {
 				continue
 			}
-			e.Append(ec.f(ext, d))
+			if ec.filter != nil && !ec.filter.Check(d) {
+				continue
+			}
+			if ctx.Err() != nil {
+				e.Append(aborted(ctx))
+				return e
+			}
+			r := ec.f(ctx, ext, d)
+			r.SetCheck(ec.name)
+			e.Append(r)
 		}
 	}
 
@@ -57,3 +92,56 @@ func (ex extensions) Check(ext pkix.Extension, d *certdata.Data) *errors.Errors
 
 	return e
 }
+
+// Names returns the names of all registered extension checks that are not
+// disabled by Select
+func (ex extensions) Names() []string {
+	var names []string
+	for _, ec := range ex {
+		if disabled[ec.name] {
+			continue
+		}
+		names = append(names, ec.name)
+	}
+	return names
+}
+
+// CheckAll runs the registered extension checks for all extensions, when
+// ConcurrentExtensions is set each extension is checked in its own goroutine.
+// The errors are always returned in the order of the extensions.
+func (ex extensions) CheckAll(exts []pkix.Extension, d *certdata.Data) *errors.Errors {
+	return ex.CheckAllContext(context.Background(), exts, d)
+}
+
+// CheckAllContext runs the registered extension checks for all extensions like
+// CheckAll, the remaining extensions are skipped when the context is done.
+func (ex extensions) CheckAllContext(ctx context.Context, exts []pkix.Extension, d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	if !ConcurrentExtensions {
+		for _, ext := range exts {
+			if ctx.Err() != nil {
+				e.Append(aborted(ctx))
+				break
+			}
+			e.Append(ex.CheckContext(ctx, ext, d))
+		}
+		return e
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*errors.Errors, len(exts))
+	for i := range exts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = ex.CheckContext(ctx, exts[i], d)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, r := range results {
+		e.Append(r)
+	}
+	return e
+}
@@ -7,10 +7,14 @@ import (
 	_ "github.com/weyhmueller/certlint/checks/extensions/basicconstraints"
 	_ "github.com/weyhmueller/certlint/checks/extensions/crldistributionpoints"
 	_ "github.com/weyhmueller/certlint/checks/extensions/ct"
+	_ "github.com/weyhmueller/certlint/checks/extensions/ctpoison"
 	_ "github.com/weyhmueller/certlint/checks/extensions/extkeyusage"
 	_ "github.com/weyhmueller/certlint/checks/extensions/keyusage"
 	_ "github.com/weyhmueller/certlint/checks/extensions/nameconstraints"
 	_ "github.com/weyhmueller/certlint/checks/extensions/policyidentifiers"
+	_ "github.com/weyhmueller/certlint/checks/extensions/qcstatements"
 	_ "github.com/weyhmueller/certlint/checks/extensions/subjectaltname"
+	_ "github.com/weyhmueller/certlint/checks/extensions/subjectinfoaccess"
 	_ "github.com/weyhmueller/certlint/checks/extensions/subjectkeyid"
+	_ "github.com/weyhmueller/certlint/checks/extensions/tlsfeature"
 )
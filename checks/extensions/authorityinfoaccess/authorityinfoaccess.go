@@ -14,7 +14,14 @@ const checkName = "AuthorityInfoAccess Extension Check"
 var extensionOid = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 1}
 
 func init() {
-	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/authorityinfoaccess",
+		Description: "AuthorityInfoAccess extension is not critical",
+		Citation:    "RFC 5280 §4.2.2.1",
+	}, extensionOid, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"AuthorityInfoAccess extension set critical": "CERTLINT_EXT_AIA_001",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
@@ -14,7 +14,14 @@ const checkName = "AuthorityKeyId Extension Check"
 var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 35}
 
 func init() {
-	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/authoritykeyid",
+		Description: "AuthorityKeyId extension is not critical",
+		Citation:    "RFC 5280 §4.2.1.1",
+	}, extensionOid, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"AuthorityKeyId extension set critical": "CERTLINT_EXT_AKI_001",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
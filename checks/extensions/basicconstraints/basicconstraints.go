@@ -14,7 +14,14 @@ const checkName = "BasicConstraints Extension Check"
 var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 19}
 
 func init() {
-	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/basicconstraints",
+		Description: "BasicConstraints extension is critical in CA certificates",
+		Citation:    "RFC 5280 §4.2.1.9",
+	}, extensionOid, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"BasicConstraints extension must be critical in CA certificates": "CERTLINT_EXT_BC_001",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
@@ -14,7 +14,14 @@ const checkName = "CRLDistributionPoints Extension Check"
 var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 31}
 
 func init() {
-	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/crldistributionpoints",
+		Description: "CRLDistributionPoints extension is not critical",
+		Citation:    "RFC 5280 §4.2.1.13",
+	}, extensionOid, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"CRLDistributionPoints extension set critical": "CERTLINT_EXT_CRLDP_001",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
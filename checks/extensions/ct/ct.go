@@ -2,19 +2,24 @@ package ct
 
 import (
 	"crypto/x509/pkix"
-	"encoding/asn1"
 
 	"github.com/weyhmueller/certlint/certdata"
 	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/ctlogs"
 	"github.com/weyhmueller/certlint/errors"
 )
 
 const checkName = "Certificate Transparency Extension Check"
 
-var extensionOid = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
-
 func init() {
-	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/ct",
+		Description: "Certificate Transparency extension is not critical",
+		Citation:    "RFC 6962 §3.3",
+	}, ctlogs.SCTListOID, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Certificate Transparency extension set critical": "CERTLINT_EXT_CT_001",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
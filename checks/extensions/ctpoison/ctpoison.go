@@ -0,0 +1,43 @@
+package ctpoison
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "Precertificate Poison Extension Check"
+
+// asn1Null is the DER encoding of the ASN.1 NULL value of the extension
+var asn1Null = []byte{0x05, 0x00}
+
+func init() {
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/ctpoison",
+		Description: "Precertificate poison extension is critical and contains ASN.1 NULL",
+		Citation:    "RFC 6962 §3.1",
+	}, certdata.PoisonOID, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"Precertificate poison extension not set critical":  "CERTLINT_EXT_POISON_001",
+		"Precertificate poison extension must contain NULL": "CERTLINT_EXT_POISON_002",
+	})
+}
+
+// Check performs a strict verification on the extension according to the standard(s)
+//
+// https://tools.ietf.org/html/rfc6962#section-3.1
+func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	if !ex.Critical {
+		e.Err("Precertificate poison extension not set critical")
+	}
+	if !bytes.Equal(ex.Value, asn1Null) {
+		e.Err("Precertificate poison extension must contain NULL")
+	}
+
+	return e
+}
@@ -15,7 +15,15 @@ const checkName = "ExtKeyUsage Extension Check"
 var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 37}
 
 func init() {
-	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/extkeyusage",
+		Description: "ExtKeyUsage extension is only used in end entity certificates and is not critical with anyExtendedKeyUsage",
+		Citation:    "RFC 5280 §4.2.1.12",
+	}, extensionOid, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"In general ExtKeyUsage will appear only in end entity certificates":             "CERTLINT_EXT_EKU_001",
+		"ExtKeyUsage extension SHOULD NOT be critical if anyExtendedKeyUsage is present": "CERTLINT_EXT_EKU_002",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
@@ -14,7 +14,14 @@ const checkName = "KeyUsage Extension Check"
 var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 15}
 
 func init() {
-	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/keyusage",
+		Description: "KeyUsage extension is critical when present",
+		Citation:    "RFC 5280 §4.2.1.3",
+	}, extensionOid, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"KeyUsage extension SHOULD be marked as critical when present": "CERTLINT_EXT_KU_001",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
@@ -15,7 +15,15 @@ const checkName = "NameConstraints Extension Check"
 var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 30}
 
 func init() {
-	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/nameconstraints",
+		Description: "NameConstraints extension is critical and only used in CA certificates",
+		Citation:    "RFC 5280 §4.2.1.10",
+	}, extensionOid, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"NameConstraints extension set non-critical":                            "CERTLINT_EXT_NC_001",
+		"End entity certificate should not contain a NameConstraints extension": "CERTLINT_EXT_NC_002",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
@@ -13,8 +13,18 @@ const checkName = "PolicyIdentifiers Extension Check"
 
 var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 32}
 
+var anyPolicy = asn1.ObjectIdentifier{2, 5, 29, 32, 0}
+
 func init() {
-	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/policyidentifiers",
+		Description: "PolicyIdentifiers extension is not critical and end entity certificates do not use anyPolicy",
+		Citation:    "RFC 5280 §4.2.1.4",
+	}, extensionOid, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"PolicyIdentifiers extension set critical":                           "CERTLINT_EXT_POL_001",
+		"End entity certificate should not contain the anyPolicy identifier": "CERTLINT_EXT_POL_002",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
@@ -25,5 +35,15 @@ func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
 		e.Err("PolicyIdentifiers extension set critical")
 	}
 
+	// anyPolicy may only be used in CA certificates, a leaf certificate must
+	// contain the specific policies it is issued under
+	if !d.Cert.IsCA {
+		for _, p := range d.Cert.PolicyIdentifiers {
+			if p.Equal(anyPolicy) {
+				e.Err("End entity certificate should not contain the anyPolicy identifier")
+			}
+		}
+	}
+
 	return e
 }
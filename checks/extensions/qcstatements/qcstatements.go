@@ -0,0 +1,210 @@
+package qcstatements
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"net/url"
+	"strings"
+
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "QCStatements Extension Check"
+
+var extensionOid = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 3}
+
+// Statements of ETSI EN 319 412-5 and RFC 3739
+var (
+	oidQcCompliance      = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 1}
+	oidQcLimitValue      = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 2}
+	oidQcRetentionPeriod = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 3}
+	oidQcSSCD            = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 4}
+	oidQcPDS             = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 5}
+	oidQcType            = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 6}
+	oidQcCCLegislation   = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 7}
+	oidPkixQCSyntaxV1    = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 11, 1}
+	oidPkixQCSyntaxV2    = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 11, 2}
+	oidPSD2              = asn1.ObjectIdentifier{0, 4, 0, 19495, 2}
+	oidQcTypeESign       = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 6, 1}
+	oidQcTypeESeal       = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 6, 2}
+	oidQcTypeWeb         = asn1.ObjectIdentifier{0, 4, 0, 1862, 1, 6, 3}
+	knownStatements      = []asn1.ObjectIdentifier{oidQcCompliance, oidQcLimitValue, oidQcRetentionPeriod, oidQcSSCD, oidQcPDS, oidQcType, oidQcCCLegislation, oidPkixQCSyntaxV1, oidPkixQCSyntaxV2, oidPSD2}
+	knownQcTypes         = []asn1.ObjectIdentifier{oidQcTypeESign, oidQcTypeESeal, oidQcTypeWeb}
+)
+
+type qcStatement struct {
+	ID   asn1.ObjectIdentifier
+	Info asn1.RawValue `asn1:"optional"`
+}
+
+type pdsLocation struct {
+	URL      string `asn1:"ia5"`
+	Language string `asn1:"printable"`
+}
+
+type limitValue struct {
+	Currency asn1.RawValue
+	Amount   int
+	Exponent int
+}
+
+func init() {
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/qcstatements",
+		Description: "QCStatements extension contains known and consistent qualified certificate statements",
+		Citation:    "RFC 3739 §3.2.6, ETSI EN 319 412-5 §4",
+	}, extensionOid, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"QCStatements extension set critical":                            "CERTLINT_EXT_QC_001",
+		"Failed to parse QCStatements extension: %s":                     "CERTLINT_EXT_QC_002",
+		"QCStatements extension contains unknown statement %s":           "CERTLINT_EXT_QC_003",
+		"QCStatements extension contains statement %s more than once":    "CERTLINT_EXT_QC_004",
+		"QcSSCD statement without QcCompliance statement":                "CERTLINT_EXT_QC_005",
+		"Failed to parse %s statement: %s":                               "CERTLINT_EXT_QC_006",
+		"QcType statement contains no type":                              "CERTLINT_EXT_QC_007",
+		"QcType statement contains unknown type %s":                      "CERTLINT_EXT_QC_008",
+		"QcType statement contains more than one type":                   "CERTLINT_EXT_QC_009",
+		"QcPDS statement contains no PDS location":                       "CERTLINT_EXT_QC_010",
+		"QcPDS location '%s' is not an https URL":                        "CERTLINT_EXT_QC_011",
+		"QcPDS location '%s' has invalid language '%s'":                  "CERTLINT_EXT_QC_012",
+		"QcPDS statement contains no PDS in English":                     "CERTLINT_EXT_QC_013",
+		"QcRetentionPeriod statement must be a positive number of years": "CERTLINT_EXT_QC_014",
+		"QcCClegislation statement contains invalid country code '%s'":   "CERTLINT_EXT_QC_015",
+	})
+}
+
+// Check performs a strict verification on the extension according to the standard(s)
+//
+// https://tools.ietf.org/html/rfc3739#section-3.2.6
+// https://www.etsi.org/deliver/etsi_en/319400_319499/31941205/
+func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	// EN 319 412-5 §4.1, relying parties that don't process the statements must
+	// still accept the certificate
+	if ex.Critical {
+		e.Warning("QCStatements extension set critical")
+	}
+
+	var statements []qcStatement
+	if rest, err := asn1.Unmarshal(ex.Value, &statements); err != nil {
+		e.Err("Failed to parse QCStatements extension: %s", err.Error())
+		return e
+	} else if len(rest) > 0 {
+		e.Err("Failed to parse QCStatements extension: %s", "trailing data")
+		return e
+	}
+
+	seen := make(map[string]bool)
+	for _, s := range statements {
+		if seen[s.ID.String()] {
+			e.Err("QCStatements extension contains statement %s more than once", s.ID.String())
+		}
+		seen[s.ID.String()] = true
+
+		if !contains(knownStatements, s.ID) {
+			e.Notice("QCStatements extension contains unknown statement %s", s.ID.String())
+			continue
+		}
+
+		switch {
+		case s.ID.Equal(oidQcType):
+			checkQcType(e, s.Info.FullBytes)
+		case s.ID.Equal(oidQcPDS):
+			checkQcPDS(e, s.Info.FullBytes)
+		case s.ID.Equal(oidQcRetentionPeriod):
+			var years int
+			if _, err := asn1.Unmarshal(s.Info.FullBytes, &years); err != nil {
+				e.Err("Failed to parse %s statement: %s", "QcRetentionPeriod", err.Error())
+			} else if years <= 0 {
+				e.Err("QcRetentionPeriod statement must be a positive number of years")
+			}
+		case s.ID.Equal(oidQcLimitValue):
+			var lv limitValue
+			if _, err := asn1.Unmarshal(s.Info.FullBytes, &lv); err != nil {
+				e.Err("Failed to parse %s statement: %s", "QcLimitValue", err.Error())
+			}
+		case s.ID.Equal(oidQcCCLegislation):
+			var countries []string
+			if _, err := asn1.Unmarshal(s.Info.FullBytes, &countries); err != nil {
+				e.Err("Failed to parse %s statement: %s", "QcCClegislation", err.Error())
+			}
+			for _, c := range countries {
+				if len(c) != 2 || strings.ToUpper(c) != c {
+					e.Err("QcCClegislation statement contains invalid country code '%s'", c)
+				}
+			}
+		}
+	}
+
+	// A QSCD is only relevant for qualified certificates, EN 319 412-5 §4.2.2
+	if seen[oidQcSSCD.String()] && !seen[oidQcCompliance.String()] {
+		e.Err("QcSSCD statement without QcCompliance statement")
+	}
+
+	return e
+}
+
+// checkQcType verifies the QcType statement contains a single known type,
+// EN 319 412-5 §4.2.3
+func checkQcType(e *errors.Errors, info []byte) {
+	var types []asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(info, &types); err != nil {
+		e.Err("Failed to parse %s statement: %s", "QcType", err.Error())
+		return
+	}
+
+	switch {
+	case len(types) == 0:
+		e.Err("QcType statement contains no type")
+	case len(types) > 1:
+		e.Warning("QcType statement contains more than one type")
+	}
+	for _, t := range types {
+		if !contains(knownQcTypes, t) {
+			e.Err("QcType statement contains unknown type %s", t.String())
+		}
+	}
+}
+
+// checkQcPDS verifies the PDS locations are https URLs with a language code and
+// that a PDS in English is included, EN 319 412-5 §4.3.4
+func checkQcPDS(e *errors.Errors, info []byte) {
+	var locations []pdsLocation
+	if _, err := asn1.Unmarshal(info, &locations); err != nil {
+		e.Err("Failed to parse %s statement: %s", "QcPDS", err.Error())
+		return
+	}
+	if len(locations) == 0 {
+		e.Err("QcPDS statement contains no PDS location")
+		return
+	}
+
+	var english bool
+	for _, l := range locations {
+		if u, err := url.Parse(l.URL); err != nil || u.Scheme != "https" {
+			e.Err("QcPDS location '%s' is not an https URL", l.URL)
+		}
+		if len(l.Language) != 2 {
+			e.Err("QcPDS location '%s' has invalid language '%s'", l.URL, l.Language)
+		}
+		if strings.ToLower(l.Language) == "en" {
+			english = true
+		}
+	}
+	if !english {
+		e.Warning("QcPDS statement contains no PDS in English")
+	}
+}
+
+// contains returns true if oid is in list
+func contains(list []asn1.ObjectIdentifier, oid asn1.ObjectIdentifier) bool {
+	for _, o := range list {
+		if o.Equal(oid) {
+			return true
+		}
+	}
+	return false
+}
@@ -13,8 +13,33 @@ const checkName = "SubjectAltName Extension Check"
 
 var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 17}
 
+// otherNames contains the otherName types we recognize
+var otherNames = map[string]string{
+	"1.3.6.1.4.1.311.20.2.3": "Microsoft UPN",
+	"1.3.6.1.4.1.311.25.1":   "Microsoft GUID",
+	"1.3.6.1.5.5.7.8.3":      "PermanentIdentifier",
+	"1.3.6.1.5.5.7.8.4":      "HardwareModuleName",
+	"1.3.6.1.5.5.7.8.7":      "SRVName",
+	"1.3.6.1.5.5.7.8.9":      "SmtpUTF8Mailbox",
+}
+
+type otherName struct {
+	TypeID asn1.ObjectIdentifier
+	Value  asn1.RawValue `asn1:"explicit,tag:0"`
+}
+
 func init() {
-	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/subjectaltname",
+		Description: "SubjectAltName extension is not critical and contains only names expected in TLS certificates",
+		Citation:    "RFC 5280 §4.2.1.6",
+	}, extensionOid, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"SubjectAltName extension set critical":                                                                 "CERTLINT_EXT_SAN_001",
+		"Failed to parse SubjectAltName extension: %s":                                                          "CERTLINT_EXT_SAN_002",
+		"Failed to parse SubjectAltName otherName: %s":                                                          "CERTLINT_EXT_SAN_003",
+		"Certificate subjectAltName contains a %s otherName, which is not expected in a TLS server certificate": "CERTLINT_EXT_SAN_004",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
@@ -25,5 +50,32 @@ func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
 		e.Err("SubjectAltName extension set critical")
 	}
 
+	var names []asn1.RawValue
+	if _, err := asn1.Unmarshal(ex.Value, &names); err != nil {
+		e.Err("Failed to parse SubjectAltName extension: %s", err.Error())
+		return e
+	}
+
+	// A publicly trusted server certificate should only contain dNSName and
+	// iPAddress entries, otherNames are not used for TLS.
+	switch d.Type {
+	case "DV", "OV", "IV", "EV":
+		for _, n := range names {
+			if n.Class != asn1.ClassContextSpecific || n.Tag != 0 {
+				continue
+			}
+			var on otherName
+			if _, err := asn1.UnmarshalWithParams(n.FullBytes, &on, "tag:0"); err != nil {
+				e.Err("Failed to parse SubjectAltName otherName: %s", err.Error())
+				continue
+			}
+			name, ok := otherNames[on.TypeID.String()]
+			if !ok {
+				name = on.TypeID.String()
+			}
+			e.Warning("Certificate subjectAltName contains a %s otherName, which is not expected in a TLS server certificate", name)
+		}
+	}
+
 	return e
 }
@@ -0,0 +1,89 @@
+package subjectinfoaccess
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"net/url"
+
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "SubjectInfoAccess Extension Check"
+
+var extensionOid = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 11}
+
+var (
+	caRepository = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 5}
+	timeStamping = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 3}
+)
+
+type accessDescription struct {
+	Method   asn1.ObjectIdentifier
+	Location asn1.RawValue
+}
+
+func init() {
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/subjectinfoaccess",
+		Description: "SubjectInfoAccess extension contains valid access descriptions",
+		Citation:    "RFC 5280 §4.2.2.2",
+	}, extensionOid, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"SubjectInfoAccess extension set critical":                            "CERTLINT_EXT_SIA_001",
+		"Failed to parse SubjectInfoAccess extension: %s":                     "CERTLINT_EXT_SIA_002",
+		"SubjectInfoAccess extension contains no access descriptions":         "CERTLINT_EXT_SIA_003",
+		"SubjectInfoAccess caRepository is only expected in CA certificates":  "CERTLINT_EXT_SIA_004",
+		"SubjectInfoAccess contains an unknown access method (%s)":            "CERTLINT_EXT_SIA_005",
+		"SubjectInfoAccess contains an invalid URI (%s)":                      "CERTLINT_EXT_SIA_006",
+		"SubjectInfoAccess contains an URI with an non-preferred scheme (%s)": "CERTLINT_EXT_SIA_007",
+	})
+}
+
+// Check performs a strict verification on the extension according to the standard(s)
+//
+// https://tools.ietf.org/html/rfc5280#section-4.2.2.2
+//
+func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	// Conforming CAs MUST mark this extension as non-critical.
+	if ex.Critical {
+		e.Err("SubjectInfoAccess extension set critical")
+	}
+
+	var sia []accessDescription
+	if _, err := asn1.Unmarshal(ex.Value, &sia); err != nil {
+		e.Err("Failed to parse SubjectInfoAccess extension: %s", err.Error())
+		return e
+	}
+	if len(sia) == 0 {
+		e.Err("SubjectInfoAccess extension contains no access descriptions")
+	}
+
+	for _, ad := range sia {
+		switch {
+		case ad.Method.Equal(caRepository):
+			if !d.Cert.IsCA {
+				e.Warning("SubjectInfoAccess caRepository is only expected in CA certificates")
+			}
+		case ad.Method.Equal(timeStamping):
+		default:
+			e.Warning("SubjectInfoAccess contains an unknown access method (%s)", ad.Method.String())
+		}
+
+		// uniformResourceIdentifier [6] IA5String
+		if ad.Location.Class != asn1.ClassContextSpecific || ad.Location.Tag != 6 {
+			continue
+		}
+		l, err := url.Parse(string(ad.Location.Bytes))
+		if err != nil {
+			e.Err("SubjectInfoAccess contains an invalid URI (%s)", string(ad.Location.Bytes))
+		} else if l.Scheme != "http" {
+			e.Warning("SubjectInfoAccess contains an URI with an non-preferred scheme (%s)", l.Scheme)
+		}
+	}
+
+	return e
+}
@@ -14,7 +14,14 @@ const checkName = "SubjectKeyId Extension Check"
 var extensionOid = asn1.ObjectIdentifier{2, 5, 29, 14}
 
 func init() {
-	checks.RegisterExtensionCheck(checkName, extensionOid, nil, Check)
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/subjectkeyid",
+		Description: "SubjectKeyId extension is not critical",
+		Citation:    "RFC 5280 §4.2.1.2",
+	}, extensionOid, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"SubjectKeyId extension set critical": "CERTLINT_EXT_SKI_001",
+	})
 }
 
 // Check performs a strict verification on the extension according to the standard(s)
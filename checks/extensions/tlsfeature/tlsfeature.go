@@ -0,0 +1,76 @@
+package tlsfeature
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const checkName = "TLS Feature Extension Check"
+
+var extensionOid = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}
+
+// features contains the known TLS extension values of the TLS Feature
+// extension
+var features = map[int]string{
+	5:  "status_request",
+	17: "status_request_v2",
+}
+
+func init() {
+	checks.RegisterExtensionCheck(checkName, checks.Metadata{
+		ID:          "extensions/tlsfeature",
+		Description: "TLS Feature extension is a non-critical sequence of known TLS extensions, e.g. OCSP must-staple",
+		Citation:    "RFC 7633 §4",
+	}, extensionOid, nil, Check)
+	errors.RegisterCodes(map[string]string{
+		"TLS Feature extension set critical":                       "CERTLINT_EXT_TLSF_001",
+		"Failed to parse TLS Feature extension: %s":                "CERTLINT_EXT_TLSF_002",
+		"TLS Feature extension contains no features":               "CERTLINT_EXT_TLSF_003",
+		"TLS Feature extension contains unknown feature %d":        "CERTLINT_EXT_TLSF_004",
+		"TLS Feature extension contains feature %s more than once": "CERTLINT_EXT_TLSF_005",
+	})
+}
+
+// Check performs a strict verification on the extension according to the standard(s)
+//
+// https://tools.ietf.org/html/rfc7633#section-4
+func Check(ex pkix.Extension, d *certdata.Data) *errors.Errors {
+	var e = errors.New(nil)
+
+	// Clients that don't support the extension would reject the certificate
+	if ex.Critical {
+		e.Warning("TLS Feature extension set critical")
+	}
+
+	var values []int
+	if rest, err := asn1.Unmarshal(ex.Value, &values); err != nil {
+		e.Err("Failed to parse TLS Feature extension: %s", err.Error())
+		return e
+	} else if len(rest) > 0 {
+		e.Err("Failed to parse TLS Feature extension: %s", "trailing data")
+		return e
+	}
+
+	if len(values) == 0 {
+		e.Err("TLS Feature extension contains no features")
+	}
+
+	seen := make(map[int]bool)
+	for _, v := range values {
+		name, ok := features[v]
+		if !ok {
+			e.Warning("TLS Feature extension contains unknown feature %d", v)
+			continue
+		}
+		if seen[v] {
+			e.Err("TLS Feature extension contains feature %s more than once", name)
+		}
+		seen[v] = true
+	}
+
+	return e
+}
@@ -0,0 +1,27 @@
+package checks
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+func TestExtensionFilter(t *testing.T) {
+	oid := asn1.ObjectIdentifier{2, 5, 29, 19}
+	ex := extensions{{"Filter Check", oid, &Filter{Type: []string{"EV"}}, func(context.Context, pkix.Extension, *certdata.Data) *errors.Errors {
+		e := errors.New(nil)
+		e.Err("Filtered check performed")
+		return e
+	}}}
+
+	for typ, performed := range map[string]bool{"EV": true, "DV": false} {
+		e := ex.Check(pkix.Extension{Id: oid}, &certdata.Data{Type: typ})
+		if got := len(e.List()) > 0; got != performed {
+			t.Errorf("Unexpected check of %s certificate got %t, want %t", typ, got, performed)
+		}
+	}
+}
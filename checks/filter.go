@@ -13,6 +13,9 @@ type Filter struct {
 	IssuedAfter   *time.Time
 	ExpiresBefore *time.Time
 	ExpiresAfter  *time.Time
+	// SkipPrecert skips CT precertificates, for checks of properties that are
+	// only present in final certificates
+	SkipPrecert bool
 }
 
 // Check returns true if a certificate complies with the given filter
@@ -30,6 +33,10 @@ func (f *Filter) Check(d *certdata.Data) bool {
 		}
 	}
 
+	if f.SkipPrecert && d.Precert {
+		return false
+	}
+
 	// Issued before given date
 	if f.IssuedBefore != nil && !d.Cert.NotBefore.Before(*f.IssuedBefore) {
 		return false
@@ -0,0 +1,72 @@
+package checks
+
+import (
+	"sort"
+	"sync"
+)
+
+// Metadata describes why a check exists and where its rules come from
+type Metadata struct {
+	// ID is the stable identifier of the check, e.g. "certificate/validity"
+	ID string
+	// Description explains what the check verifies
+	Description string
+	// Citation lists the standard(s) the check is based on, e.g.
+	// "RFC 5280 §4.2.1.12" or "BR §7.1.2.3"
+	Citation string
+}
+
+// Check is the name and metadata of a registered check
+type Check struct {
+	Name string
+	Metadata
+}
+
+var metaMutex = &sync.RWMutex{}
+
+// metadata contains the metadata per check name
+var metadata = make(map[string]Metadata)
+
+// registerMetadata registers the metadata of a check
+func registerMetadata(name string, m Metadata) {
+	metaMutex.Lock()
+	metadata[name] = m
+	metaMutex.Unlock()
+}
+
+// Lookup returns the metadata of a check by name or ID
+func Lookup(name string) (Metadata, bool) {
+	metaMutex.RLock()
+	defer metaMutex.RUnlock()
+	if m, ok := metadata[name]; ok {
+		return m, true
+	}
+	for _, m := range metadata {
+		if m.ID == name {
+			return m, true
+		}
+	}
+	return Metadata{}, false
+}
+
+// Reference returns the standards reference of a check, or an empty string if
+// the check has no citation.
+func Reference(name string) string {
+	m, _ := Lookup(name)
+	return m.Citation
+}
+
+// All returns all registered checks sorted by ID
+func All() []Check {
+	metaMutex.RLock()
+	l := make([]Check, 0, len(metadata))
+	for name, m := range metadata {
+		l = append(l, Check{name, m})
+	}
+	metaMutex.RUnlock()
+
+	sort.Slice(l, func(i, j int) bool {
+		return l[i].ID < l[j].ID
+	})
+	return l
+}
@@ -0,0 +1,72 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// extensionsCheck is the certificate check that runs the extension checks
+const extensionsCheck = "Extensions Check"
+
+// disabled contains the names of the checks that are not run, set by Select
+var disabled map[string]bool
+
+// Select runs only the checks with the given names, or all checks when only is
+// empty, except the excluded checks. The Extensions Check that runs the
+// extension checks is also selected when only contains an extension check.
+// Checks are given by name or ID, which are not case sensitive, an error is
+// returned for unknown checks.
+func Select(only, exclude []string) error {
+	var all []string
+	for _, cc := range Certificate {
+		all = append(all, cc.name)
+	}
+	for _, ec := range Extensions {
+		all = append(all, ec.name)
+	}
+	for _, cc := range CSR {
+		all = append(all, cc.name)
+	}
+
+	lookup := func(name string) (string, error) {
+		name = strings.TrimSpace(name)
+		for _, n := range all {
+			if m, _ := Lookup(n); strings.EqualFold(n, name) || strings.EqualFold(m.ID, name) {
+				return n, nil
+			}
+		}
+		return "", fmt.Errorf("unknown check '%s'", name)
+	}
+
+	d := make(map[string]bool)
+	if len(only) > 0 {
+		keep := make(map[string]bool)
+		for _, name := range only {
+			n, err := lookup(name)
+			if err != nil {
+				return err
+			}
+			keep[n] = true
+			for _, ec := range Extensions {
+				if ec.name == n {
+					keep[extensionsCheck] = true
+				}
+			}
+		}
+		for _, n := range all {
+			if !keep[n] {
+				d[n] = true
+			}
+		}
+	}
+	for _, name := range exclude {
+		n, err := lookup(name)
+		if err != nil {
+			return err
+		}
+		d[n] = true
+	}
+
+	disabled = d
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import "github.com/weyhmueller/certlint/errors"
+
+// init registers the stable codes of the findings that are not reported by a
+// check, see errors.RegisterCodes
+func init() {
+	errors.RegisterCodes(map[string]string{
+		// certlint.go
+		"Failed to parse certificate: %s":                           "CERTLINT_CERT_001",
+		"Failed to load issuer certificate: %s":                     "CERTLINT_CERT_002",
+		"Certificate does not chain to a root in the bulk file: %s": "CERTLINT_CERT_003",
+		"Failed to verify chain for %s":                             "CERTLINT_CERT_004",
+		"This Certificate is acceptable":                            "CERTLINT_CERT_005",
+		"Failed to parse PKCS#7 bundle: %s":                         "CERTLINT_CERT_006",
+		"Failed to download issuer certificate from '%s': %s":       "CERTLINT_CERT_007",
+		"Signature not from downloaded issuer: %s":                  "CERTLINT_CERT_008",
+		"Failed to fetch issuer certificate: %s":                    "CERTLINT_CERT_009",
+		"Issuer not fetched in offline mode, chain checks skipped":  "CERTLINT_CERT_010",
+		// csr.go
+		"Failed to parse CSR: %s": "CERTLINT_CSR_001",
+		"This CSR is acceptable":  "CERTLINT_CSR_002",
+		// precert.go
+		"Failed to parse precertificate: %s":                           "CERTLINT_PRECERT_001",
+		"Precertificate does not contain the poison extension":         "CERTLINT_PRECERT_002",
+		"Certificate does not match the precertificate TBSCertificate": "CERTLINT_PRECERT_003",
+		"Certificate serial number differs from the precertificate":    "CERTLINT_PRECERT_004",
+		"Certificate issuer differs from the precertificate":           "CERTLINT_PRECERT_005",
+		"Certificate %s differs from the precertificate":               "CERTLINT_PRECERT_006",
+		"Certificate extension %s differs from the precertificate":     "CERTLINT_PRECERT_007",
+		// normalize.go
+		"Failed to normalize certificate: %s":                                                         "CERTLINT_NORM_001",
+		"Certificate is not DER encoded, the encoding changed during normalization":                   "CERTLINT_NORM_002",
+		"Failed to write normalized certificate: %s":                                                  "CERTLINT_NORM_003",
+		"Normalized certificate signature is no longer valid, the certificate has to be signed again": "CERTLINT_NORM_004",
+		// aggregate.go
+		"Certificates issued by '%s' use different SubjectKeyId methods: %s":                                                     "CERTLINT_AGG_001",
+		"Certificates issued by '%s' share serial number %s: %s":                                                                 "CERTLINT_AGG_002",
+		"Certificates issued by '%s' use sequential serial numbers, %d of %d follow closely on the previous serial number":       "CERTLINT_AGG_003",
+		"Certificates issued by '%s' have serial numbers in which only %d bits vary, expected at least 64 bits of CSPRNG output": "CERTLINT_AGG_004",
+		// zlint.go
+		"Failed to run zlint: %s": "CERTLINT_ZLINT_001",
+		"zlint: %s":               "CERTLINT_ZLINT_002",
+		// certstream.go
+		"Unknown CertStream update type %s": "CERTLINT_SOURCE_001",
+		// crtsh.go
+		"Failed to download certificate: %s": "CERTLINT_SOURCE_002",
+		// ctlog.go
+		"Failed to parse log entry: %s": "CERTLINT_SOURCE_003",
+	})
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// csvCertificate is a result with the values that are computed once per
+// certificate for the CSV report
+type csvCertificate struct {
+	testResult
+	Number  int
+	Revoked string
+	Include bool
+}
+
+// csvColumn defines the header and the value of a column in the CSV report,
+// columns with cert set are empty when the certificate could not be parsed.
+type csvColumn struct {
+	Header string
+	cert   bool
+	value  func(c *csvCertificate, e errors.Err) string
+}
+
+// reportColumns contains all available CSV report columns
+var reportColumns = map[string]csvColumn{
+	"source": {"Source", false, func(c *csvCertificate, e errors.Err) string {
+		return c.Source
+	}},
+	"number": {"Number", false, func(c *csvCertificate, e errors.Err) string {
+		return fmt.Sprintf("%d", c.Number)
+	}},
+	"issuer": {"Issuer", true, func(c *csvCertificate, e errors.Err) string {
+		return fmt.Sprintf("%s, %s", c.Cert.Issuer.CommonName, c.Cert.Issuer.Organization)
+	}},
+	"ca-operator": {"CA Operator", true, func(c *csvCertificate, e errors.Err) string {
+		return caOperator(c.Cert)
+	}},
+	"cn": {"CN", true, func(c *csvCertificate, e errors.Err) string {
+		return c.Cert.Subject.CommonName
+	}},
+	"o": {"O", true, func(c *csvCertificate, e errors.Err) string {
+		return strings.Join(c.Cert.Subject.Organization, ", ")
+	}},
+	"serial": {"Serial", true, func(c *csvCertificate, e errors.Err) string {
+		return formatSerial(c.Cert.SerialNumber)
+	}},
+	"notbefore": {"NotBefore", true, func(c *csvCertificate, e errors.Err) string {
+		return c.Cert.NotBefore.Format("2006-01-02")
+	}},
+	"notafter": {"NotAfter", true, func(c *csvCertificate, e errors.Err) string {
+		return c.Cert.NotAfter.Format("2006-01-02")
+	}},
+	"type": {"Type", true, func(c *csvCertificate, e errors.Err) string {
+		return c.Type
+	}},
+	"precert": {"Precert", true, func(c *csvCertificate, e errors.Err) string {
+		return fmt.Sprintf("%t", c.Precert)
+	}},
+	"severity": {"Severity", false, func(c *csvCertificate, e errors.Err) string {
+		return strings.ToUpper(e.Priority().String())
+	}},
+	"linter": {"Linter", false, func(c *csvCertificate, e errors.Err) string {
+		if e.Check() == zlintCheck {
+			return "zlint"
+		}
+		return "certlint"
+	}},
+	"check": {"Check", false, func(c *csvCertificate, e errors.Err) string {
+		return e.Check()
+	}},
+	"code": {"Code", false, func(c *csvCertificate, e errors.Err) string {
+		return e.Code()
+	}},
+	"count": {"Count", false, func(c *csvCertificate, e errors.Err) string {
+		return fmt.Sprintf("%d", e.Count())
+	}},
+	"error": {"Error", false, func(c *csvCertificate, e errors.Err) string {
+		return e.Error()
+	}},
+	"trusted-by": {"Trusted By", true, func(c *csvCertificate, e errors.Err) string {
+		return strings.Join(c.TrustedBy, ", ")
+	}},
+	"revoked": {"Revoked", true, func(c *csvCertificate, e errors.Err) string {
+		return c.Revoked
+	}},
+	"cert": {"Cert", false, func(c *csvCertificate, e errors.Err) string {
+		if c.Cert == nil {
+			return c.Pem
+		}
+		if !c.Include {
+			return ""
+		}
+		return string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: c.Der,
+		}))
+	}},
+	"fingerprint": {"Fingerprint", true, func(c *csvCertificate, e errors.Err) string {
+		return fmt.Sprintf("%x", sha256.Sum256(c.Cert.Raw))
+	}},
+	"spki-sha256": {"SPKI SHA-256", true, func(c *csvCertificate, e errors.Err) string {
+		return fmt.Sprintf("%x", sha256.Sum256(c.Cert.RawSubjectPublicKeyInfo))
+	}},
+	"san": {"SAN", true, func(c *csvCertificate, e errors.Err) string {
+		return strings.Join(subjectAltNames(c.Cert), ", ")
+	}},
+}
+
+// defaultColumns are the columns of the CSV report when no columns are given
+var defaultColumns = []string{"number", "issuer", "ca-operator", "cn", "o", "serial", "notbefore", "notafter", "type", "severity", "error", "revoked", "cert"}
+
+// csvColumns contains the columns of the CSV report
+var csvColumns = defaultColumns
+
+// parseColumns parses a comma separated list of CSV report columns
+func parseColumns(s string) ([]string, error) {
+	var columns []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := reportColumns[name]; !ok {
+			return nil, fmt.Errorf("Unknown report column '%s', available columns: %s", name, strings.Join(columnNames(), ","))
+		}
+		columns = append(columns, name)
+	}
+	return columns, nil
+}
+
+// columnNames returns the sorted names of all available CSV report columns
+func columnNames() []string {
+	var names []string
+	for name := range reportColumns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// csvHeader returns the header row of the CSV report
+func csvHeader(columns []string) []string {
+	row := make([]string, len(columns))
+	for i, name := range columns {
+		row[i] = reportColumns[name].Header
+	}
+	return row
+}
+
+// csvRow returns the CSV report row of a finding, c is nil for the findings
+// over all certificates.
+func csvRow(columns []string, c *csvCertificate, e errors.Err) []string {
+	row := make([]string, len(columns))
+	for i, name := range columns {
+		col := reportColumns[name]
+		switch {
+		case c == nil:
+			if name == "severity" || name == "check" || name == "error" {
+				row[i] = col.value(nil, e)
+			}
+		case col.cert && c.Cert == nil:
+			// Certificate could not be parsed
+		default:
+			row[i] = col.value(c, e)
+		}
+	}
+	return row
+}
+
+// subjectAltNames returns all dNSNames, iPAddresses, rfc822Names and URIs of a
+// certificate
+func subjectAltNames(c *x509.Certificate) []string {
+	names := append([]string{}, c.DNSNames...)
+	for _, ip := range c.IPAddresses {
+		names = append(names, ip.String())
+	}
+	names = append(names, c.EmailAddresses...)
+	for _, u := range c.URIs {
+		names = append(names, u.String())
+	}
+	return names
+}
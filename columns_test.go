@@ -0,0 +1,56 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+func TestParseColumns(t *testing.T) {
+	columns, err := parseColumns("fingerprint, Issuer,type,severity,error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(columns, []string{"fingerprint", "issuer", "type", "severity", "error"}) {
+		t.Errorf("Unexpected columns %v", columns)
+	}
+
+	if _, err = parseColumns("serial,unknown"); err == nil {
+		t.Error("Expected an error for an unknown column")
+	}
+
+	// All default columns must exist
+	if _, err = parseColumns(strings.Join(defaultColumns, ",")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCSVRow(t *testing.T) {
+	issuer := "./testdata/golden/issuer.pem"
+	r := do(nil, getCertificate("./testdata/uppercasesan.pem"), &issuer, true, true)
+	c := &csvCertificate{testResult: r, Number: 3}
+	columns := []string{"number", "serial", "san", "cert", "severity", "error"}
+
+	var e = errors.New(nil)
+	e.Warning("test finding")
+	finding := e.List()[0]
+
+	row := csvRow(columns, c, finding)
+	expected := []string{"3", formatSerial(r.Cert.SerialNumber), "WWW.Example.COM", "", "WARNING", "test finding"}
+	if !reflect.DeepEqual(row, expected) {
+		t.Errorf("Expected row %q, got %q", expected, row)
+	}
+
+	// Certificate columns are empty for findings over all certificates
+	row = csvRow(columns, nil, finding)
+	expected = []string{"", "", "", "", "WARNING", "test finding"}
+	if !reflect.DeepEqual(row, expected) {
+		t.Errorf("Expected row %q, got %q", expected, row)
+	}
+
+	if h := csvHeader(columns); h[2] != "SAN" {
+		t.Errorf("Expected header SAN, got %s", h[2])
+	}
+}
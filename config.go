@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadConfig reads a YAML configuration file using the flag names as keys, the
+// values are used for all flags that are not set on the command line. Lists
+// are joined with commas, e.g.
+//
+//	checks:
+//	  - Key Usage Check
+//	  - KeyUsage Extension Check
+//	severity-overrides: overrides.json
+//	min-severity: warning
+//	report-format: sarif
+func loadConfig(file string, fs *flag.FlagSet) error {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return fmt.Errorf("%s: %s", file, err.Error())
+	}
+
+	// Options on the command line take precedence
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	// Sort the options to always report the same error first
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if fs.Lookup(name) == nil || name == "config" {
+			return fmt.Errorf("%s: unknown option '%s'", file, name)
+		}
+		if set[name] {
+			continue
+		}
+
+		v, err := configValue(m[name])
+		if err != nil {
+			return fmt.Errorf("%s: %s: %s", file, name, err.Error())
+		}
+		if err := fs.Set(name, v); err != nil {
+			return fmt.Errorf("%s: %s: %s", file, name, err.Error())
+		}
+	}
+	return nil
+}
+
+// configValue returns the flag value of a YAML scalar or list
+func configValue(v interface{}) (string, error) {
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return t, nil
+	case bool, int, int64, float64:
+		return fmt.Sprint(t), nil
+	case []interface{}:
+		items := make([]string, 0, len(t))
+		for _, i := range t {
+			s, err := configValue(i)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	}
+	return "", fmt.Errorf("unsupported value %v", v)
+}
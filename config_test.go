@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "certlint.yaml")
+	err := ioutil.WriteFile(file, []byte(`# Private PKI review
+checks:
+  - Key Usage Check
+  - KeyUsage Extension Check
+min-severity: warning
+expired: true
+report: review.csv
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := flag.NewFlagSet("certlint", flag.ContinueOnError)
+	checks := fs.String("checks", "", "")
+	minSev := fs.String("min-severity", "", "")
+	expired := fs.Bool("expired", false, "")
+	report := fs.String("report", "report.csv", "")
+	if err = fs.Parse([]string{"-report", "cli.csv"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = loadConfig(file, fs); err != nil {
+		t.Fatal(err)
+	}
+	if *checks != "Key Usage Check,KeyUsage Extension Check" {
+		t.Errorf("Unexpected checks %s", *checks)
+	}
+	if *minSev != "warning" || !*expired {
+		t.Errorf("Unexpected min-severity %s or expired %t", *minSev, *expired)
+	}
+	if *report != "cli.csv" {
+		t.Errorf("Expected the report of the command line, got %s", *report)
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"unknown.yaml": "no-such-option: true\n",
+		"bool.yaml":    "expired: maybe\n",
+		"config.yaml":  "config: other.yaml\n",
+	} {
+		file := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		fs := flag.NewFlagSet("certlint", flag.ContinueOnError)
+		fs.Bool("expired", false, "")
+		fs.String("config", "", "")
+		if err := loadConfig(file, fs); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+
+	if err := loadConfig(filepath.Join(dir, "missing.yaml"), flag.NewFlagSet("certlint", flag.ContinueOnError)); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// connectTimeout is the maximum time to connect and complete the handshake
+const connectTimeout = 10 * time.Second
+
+// fetchChain performs a TLS handshake with addr and returns the certificate
+// chain as presented by the server. The chain is not verified, the findings
+// are reported by the checks. The host of addr is used for SNI when no
+// serverName is given. If protocol is set the connection is upgraded to TLS
+// using STARTTLS.
+func fetchChain(addr, serverName, protocol string) ([][]byte, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if len(serverName) == 0 && net.ParseIP(host) == nil {
+		serverName = host
+	}
+
+	conn, err := net.DialTimeout("tcp", addr, connectTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(connectTimeout))
+
+	if len(protocol) > 0 {
+		domain := serverName
+		if len(domain) == 0 {
+			domain = host
+		}
+		if err = starttls(conn, protocol, domain); err != nil {
+			return nil, fmt.Errorf("%s STARTTLS failed: %s", protocol, err.Error())
+		}
+	}
+
+	tc := tls.Client(conn, &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true,
+	})
+	if err = tc.Handshake(); err != nil {
+		return nil, err
+	}
+
+	var ders [][]byte
+	for _, c := range tc.ConnectionState().PeerCertificates {
+		ders = append(ders, c.Raw)
+	}
+	return ders, nil
+}
+
+// starttls negotiates the upgrade to TLS of a plain text protocol
+func starttls(conn net.Conn, protocol, domain string) error {
+	r := bufio.NewReader(conn)
+
+	switch protocol {
+	case "smtp":
+		// RFC 3207
+		if err := smtpReply(r, "220"); err != nil {
+			return err
+		}
+		fmt.Fprintf(conn, "EHLO certlint\r\n")
+		if err := smtpReply(r, "250"); err != nil {
+			return err
+		}
+		fmt.Fprintf(conn, "STARTTLS\r\n")
+		return smtpReply(r, "220")
+
+	case "imap":
+		// RFC 3501 §6.2.1
+		if err := expectLine(r, "* OK"); err != nil {
+			return err
+		}
+		fmt.Fprintf(conn, "a001 STARTTLS\r\n")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(line, "a001 ") {
+				if !strings.HasPrefix(line, "a001 OK") {
+					return fmt.Errorf("unexpected response '%s'", strings.TrimSpace(line))
+				}
+				return nil
+			}
+		}
+
+	case "pop3":
+		// RFC 2595 §4
+		if err := expectLine(r, "+OK"); err != nil {
+			return err
+		}
+		fmt.Fprintf(conn, "STLS\r\n")
+		return expectLine(r, "+OK")
+
+	case "ldap":
+		// RFC 4511 §4.14
+		return ldapStartTLS(conn, r)
+
+	case "xmpp":
+		// RFC 6120 §5.4.2
+		fmt.Fprintf(conn, "<?xml version='1.0'?><stream:stream to='%s' xmlns='jabber:client' xmlns:stream='http://etherx.jabber.org/streams' version='1.0'>", domain)
+		if err := readUntil(r, "</stream:features>"); err != nil {
+			return err
+		}
+		fmt.Fprintf(conn, "<starttls xmlns='urn:ietf:params:xml:ns:xmpp-tls'/>")
+		return readUntil(r, "<proceed")
+	}
+
+	return fmt.Errorf("unsupported protocol")
+}
+
+// smtpReply reads a (multiline) SMTP reply and checks the reply code
+func smtpReply(r *bufio.Reader, code string) error {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(line, code) {
+			return fmt.Errorf("unexpected response '%s'", strings.TrimSpace(line))
+		}
+		// The last line of a reply has a space after the code
+		if len(line) < 4 || line[3] != '-' {
+			return nil
+		}
+	}
+}
+
+// expectLine reads a line and checks it starts with prefix
+func expectLine(r *bufio.Reader, prefix string) error {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, prefix) {
+		return fmt.Errorf("unexpected response '%s'", strings.TrimSpace(line))
+	}
+	return nil
+}
+
+// readUntil reads until s has been received
+func readUntil(r *bufio.Reader, s string) error {
+	var buf []byte
+	for !bytes.Contains(buf, []byte(s)) {
+		b, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		buf = append(buf, b)
+	}
+	return nil
+}
+
+// oidStartTLS is the LDAP StartTLS extended operation
+const oidStartTLS = "1.3.6.1.4.1.1466.20037"
+
+// RFC 4511 §4.12
+type ldapExtendedRequest struct {
+	MessageID int
+	Request   struct {
+		Name []byte `asn1:"tag:0"`
+	} `asn1:"application,tag:23"`
+}
+
+type ldapExtendedResponse struct {
+	MessageID int
+	Response  struct {
+		ResultCode        asn1.Enumerated
+		MatchedDN         []byte
+		DiagnosticMessage []byte
+	} `asn1:"application,tag:24"`
+}
+
+// ldapStartTLS sends the StartTLS extended request and checks the resultCode
+// of the extended response
+func ldapStartTLS(conn net.Conn, r *bufio.Reader) error {
+	var req ldapExtendedRequest
+	req.MessageID = 1
+	req.Request.Name = []byte(oidStartTLS)
+	b, err := asn1.Marshal(req)
+	if err != nil {
+		return err
+	}
+	if _, err = conn.Write(b); err != nil {
+		return err
+	}
+
+	// Read the tag and length of the LDAPMessage
+	head := make([]byte, 2)
+	if _, err = io.ReadFull(r, head); err != nil {
+		return err
+	}
+	length := int(head[1])
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 4 {
+			return fmt.Errorf("invalid LDAP message length")
+		}
+		lb := make([]byte, n)
+		if _, err = io.ReadFull(r, lb); err != nil {
+			return err
+		}
+		head = append(head, lb...)
+		length = 0
+		for _, b := range lb {
+			length = length<<8 | int(b)
+		}
+	}
+	body := make([]byte, length)
+	if _, err = io.ReadFull(r, body); err != nil {
+		return err
+	}
+
+	var resp ldapExtendedResponse
+	if _, err = asn1.Unmarshal(append(head, body...), &resp); err != nil {
+		return err
+	}
+	if resp.Response.ResultCode != 0 {
+		return fmt.Errorf("resultCode %d: %s", resp.Response.ResultCode, resp.Response.DiagnosticMessage)
+	}
+	return nil
+}
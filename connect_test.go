@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/asn1"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchChain(t *testing.T) {
+	var serverName string
+	srv := httptest.NewUnstartedServer(http.NotFoundHandler())
+	srv.TLS = &tls.Config{
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			serverName = hello.ServerName
+			return nil, nil
+		},
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	ders, err := fetchChain(srv.Listener.Addr().String(), "www.example.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ders) != 1 || !bytes.Equal(ders[0], srv.Certificate().Raw) {
+		t.Error("Expected the certificate presented by the server")
+	}
+	if serverName != "www.example.com" {
+		t.Errorf("Expected SNI www.example.com, got '%s'", serverName)
+	}
+
+	// No SNI is sent for IP addresses
+	if _, err = fetchChain(srv.Listener.Addr().String(), "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if serverName != "" {
+		t.Errorf("Expected no SNI, got '%s'", serverName)
+	}
+}
+
+func TestFetchChainSTARTTLS(t *testing.T) {
+	// Borrow the certificate of a test server
+	ts := httptest.NewUnstartedServer(http.NotFoundHandler())
+	ts.StartTLS()
+	ts.Close()
+	config := &tls.Config{Certificates: ts.TLS.Certificates}
+
+	tests := map[string]func(net.Conn, *bufio.Reader) bool{
+		"smtp": func(c net.Conn, r *bufio.Reader) bool {
+			fmt.Fprintf(c, "220-mx.example.com ESMTP\r\n220 ready\r\n")
+			r.ReadString('\n')
+			fmt.Fprintf(c, "250-mx.example.com\r\n250 STARTTLS\r\n")
+			line, _ := r.ReadString('\n')
+			fmt.Fprintf(c, "220 go ahead\r\n")
+			return line == "STARTTLS\r\n"
+		},
+		"imap": func(c net.Conn, r *bufio.Reader) bool {
+			fmt.Fprintf(c, "* OK ready\r\n")
+			line, _ := r.ReadString('\n')
+			fmt.Fprintf(c, "* CAPABILITY IMAP4rev1\r\na001 OK begin TLS\r\n")
+			return line == "a001 STARTTLS\r\n"
+		},
+		"pop3": func(c net.Conn, r *bufio.Reader) bool {
+			fmt.Fprintf(c, "+OK ready\r\n")
+			line, _ := r.ReadString('\n')
+			fmt.Fprintf(c, "+OK begin TLS\r\n")
+			return line == "STLS\r\n"
+		},
+		"ldap": func(c net.Conn, r *bufio.Reader) bool {
+			buf := make([]byte, 512)
+			n, _ := r.Read(buf)
+			var req ldapExtendedRequest
+			asn1.Unmarshal(buf[:n], &req)
+			var resp ldapExtendedResponse
+			resp.MessageID = req.MessageID
+			b, _ := asn1.Marshal(resp)
+			c.Write(b)
+			return string(req.Request.Name) == oidStartTLS
+		},
+		"xmpp": func(c net.Conn, r *bufio.Reader) bool {
+			readUntil(r, "version='1.0'>")
+			fmt.Fprintf(c, "<stream:stream from='example.com' version='1.0'><stream:features><starttls xmlns='urn:ietf:params:xml:ns:xmpp-tls'><required/></starttls></stream:features>")
+			readUntil(r, "/>")
+			fmt.Fprintf(c, "<proceed xmlns='urn:ietf:params:xml:ns:xmpp-tls'/>")
+			return true
+		},
+		"smtp-refused": func(c net.Conn, r *bufio.Reader) bool {
+			fmt.Fprintf(c, "220 ready\r\n")
+			r.ReadString('\n')
+			fmt.Fprintf(c, "250 mx.example.com\r\n")
+			r.ReadString('\n')
+			fmt.Fprintf(c, "454 TLS not available\r\n")
+			return false
+		},
+	}
+
+	for protocol, server := range tests {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ok := make(chan bool, 1)
+		go func() {
+			c, err := l.Accept()
+			if err != nil {
+				ok <- false
+				return
+			}
+			defer c.Close()
+			if server(c, bufio.NewReader(c)) {
+				ok <- tls.Server(c, config).Handshake() == nil
+				return
+			}
+			ok <- false
+		}()
+
+		ders, err := fetchChain(l.Addr().String(), "mail.example.com", strings.TrimSuffix(protocol, "-refused"))
+		l.Close()
+		if strings.HasSuffix(protocol, "-refused") {
+			if err == nil {
+				t.Errorf("%s: expected an error", protocol)
+			}
+			<-ok
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", protocol, err)
+			continue
+		}
+		if !<-ok {
+			t.Errorf("%s: unexpected STARTTLS negotiation", protocol)
+		}
+		if len(ders) != 1 {
+			t.Errorf("%s: expected 1 certificate, got %d", protocol, len(ders))
+		}
+	}
+}
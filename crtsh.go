@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// crtshURL is the crt.sh endpoint used to search and download certificates
+var crtshURL = "https://crt.sh/"
+
+// crtshClient is used for all crt.sh requests, searches can be slow
+var crtshClient = &http.Client{Timeout: 2 * time.Minute}
+
+// crtshQuery returns the crt.sh search url for a domain, organization or CA ID
+// query, expired certificates are only included when exp is set.
+func crtshQuery(query, by string, exp bool) (string, error) {
+	v := url.Values{}
+	switch by {
+	case "domain":
+		v.Set("q", query)
+	case "org":
+		v.Set("O", query)
+	case "caid":
+		v.Set("caid", query)
+	default:
+		return "", fmt.Errorf("unsupported crt.sh query type %s, use domain, org or caid", by)
+	}
+	v.Set("output", "json")
+	if !exp {
+		v.Set("exclude", "expired")
+	}
+	return crtshURL + "?" + v.Encode(), nil
+}
+
+// doCrtSh queues all certificates matching a crt.sh query, the crt.sh link of
+// each certificate is included in the results.
+func doCrtSh(query, by string, exp bool) {
+	defer close(jobs)
+
+	ids, err := crtshSearch(query, by, exp)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, id := range ids {
+		source := fmt.Sprintf("%s?id=%d", crtshURL, id)
+		der, err := crtshDownload(id)
+		if err != nil {
+			var e = errors.New(nil)
+			e.Err("Failed to download certificate: %s", err.Error())
+			notePriority(e)
+			results <- testResult{Source: source, Errors: e}
+			continue
+		}
+
+		count++
+		jobs <- bulkJob{der: der, source: source}
+	}
+
+	fmt.Printf("Checked %d certificates\n", count)
+}
+
+// crtshSearch returns the unique crt.sh IDs of the certificates matching the
+// query.
+func crtshSearch(query, by string, exp bool) ([]int64, error) {
+	u, err := crtshQuery(query, by, exp)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := crtshClient.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("crt.sh search failed: %s", resp.Status)
+	}
+
+	var entries []struct {
+		ID int64 `json:"id"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("crt.sh search failed: %s", err.Error())
+	}
+
+	// A certificate is listed once for every matching identity
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, e := range entries {
+		if !seen[e.ID] {
+			seen[e.ID] = true
+			ids = append(ids, e.ID)
+		}
+	}
+	return ids, nil
+}
+
+// crtshDownload returns the DER bytes of a certificate by its crt.sh ID
+func crtshDownload(id int64) ([]byte, error) {
+	resp, err := crtshClient.Get(fmt.Sprintf("%s?d=%d", crtshURL, id))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected response '%s'", resp.Status)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCertificate(b)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCrtshQuery(t *testing.T) {
+	for _, c := range []struct {
+		by       string
+		exp      bool
+		expected string
+	}{
+		{"domain", false, "https://crt.sh/?exclude=expired&output=json&q=example.com"},
+		{"org", true, "https://crt.sh/?O=example.com&output=json"},
+		{"caid", false, "https://crt.sh/?caid=example.com&exclude=expired&output=json"},
+	} {
+		u, err := crtshQuery("example.com", c.by, c.exp)
+		if err != nil {
+			t.Error(err)
+		}
+		if u != c.expected {
+			t.Errorf("Expected %s, got %s", c.expected, u)
+		}
+	}
+
+	if _, err := crtshQuery("example.com", "serial", false); err == nil {
+		t.Error("Expected an error for an unsupported query type")
+	}
+}
+
+func TestDoCrtSh(t *testing.T) {
+	defer func(j chan bulkJob, r chan testResult, c int64, u string) {
+		jobs, results, count, crtshURL = j, r, c, u
+	}(jobs, results, count, crtshURL)
+	jobs = make(chan bulkJob, 100)
+	results = make(chan testResult, 100)
+
+	pem, err := ioutil.ReadFile("testdata/nokeyusage.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("d") {
+		case "":
+			if r.URL.Query().Get("caid") != "42" {
+				http.Error(w, "unexpected query", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `[{"id":1,"name_value":"a.example.com"},{"id":1,"name_value":"b.example.com"},{"id":2},{"id":3}]`)
+		case "3":
+			http.NotFound(w, r)
+		default:
+			w.Write(pem)
+		}
+	}))
+	defer ts.Close()
+	crtshURL = ts.URL + "/"
+
+	doCrtSh("42", "caid", false)
+
+	var sources []string
+	for job := range jobs {
+		sources = append(sources, job.source)
+	}
+	if len(sources) != 2 || sources[0] != crtshURL+"?id=1" || sources[1] != crtshURL+"?id=2" {
+		t.Errorf("Expected certificates 1 and 2, got %v", sources)
+	}
+
+	select {
+	case r := <-results:
+		if r.Source != crtshURL+"?id=3" || len(r.Errors.List()) != 1 {
+			t.Errorf("Expected a download error for certificate 3, got %v", r.Errors.List())
+		}
+	default:
+		t.Error("Expected a result for the missing certificate")
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/weyhmueller/certlint/asn1"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// readCSR reads a PEM or DER encoded certificate signing request from file
+func readCSR(file string) ([]byte, error) {
+	b, err := readFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// decode pem
+	if block, _ := pem.Decode(b); block != nil {
+		if block.Type != "CERTIFICATE REQUEST" && block.Type != "NEW CERTIFICATE REQUEST" {
+			return nil, fmt.Errorf("%s: PEM block of type %s is not a certificate request", file, block.Type)
+		}
+		return block.Bytes, nil
+	}
+
+	// A DER encoded certificate request always starts with a SEQUENCE
+	if len(b) == 0 || b[0] != 0x30 {
+		return nil, fmt.Errorf("%s: no PEM or DER encoded certificate request found", file)
+	}
+	return b, nil
+}
+
+// doCSR performs the checks on the der encoding and the parsed certificate
+// signing request
+func doCSR(der []byte) testResult {
+	var result testResult
+	result.Type = "CSR"
+	result.Der = der
+	result.Errors = errors.New(&errors.Config{Deduplicate: true})
+
+	al := new(asn1.Linter)
+	result.Errors.Append(al.CheckStruct(der))
+
+	r, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		result.Errors.Err("Failed to parse CSR: %s", err)
+		return result
+	}
+	result.Errors.Append(checks.CSR.Check(r))
+
+	if len(result.Errors.List(errors.Notice, errors.Warning, errors.Error, errors.Alert, errors.Critical, errors.Emergency)) == 0 {
+		result.Errors.Info("This CSR is acceptable")
+	}
+
+	return result
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSR(t *testing.T) {
+	tests := map[string][]string{
+		"cacsr.pem": {
+			"CSR contains a challengePassword attribute",
+			"CSR requests a CA certificate in the basicConstraints extension",
+			"CSR requests the keyCertSign or cRLSign key usage",
+		},
+		"weakkey.pem": {"CSR key too small"},
+		"names.pem": {
+			"CSR requested name 'intranet' is not a fully qualified domain name",
+			"CSR requested name 'foo_bar.example.com' contains the invalid character '_'",
+			"CSR requested name 'www.*.example.com' contains the invalid character '*'",
+			"CSR requested name 'Upper.Example.com' contains uppercase characters",
+			"CSR requests the private or reserved IP address 10.0.0.1",
+			"CSR commonName 'Other.example.com' is not requested in the subjectAltName",
+		},
+		"sha1.pem":  {"CSR is signed using the insecure SHA1-RSA signature algorithm"},
+		"nosan.pem": {"CSR requests no subjectAltName"},
+		"badsig.pem": {
+			"CSR signature is invalid, proof of possession failed",
+		},
+		"subjectencoding.pem": {
+			"CSR subject countryName must be a two letter PrintableString",
+			"CSR subject attribute 2.5.4.10 uses a deprecated string type",
+			"CSR subject attribute 2.5.4.11 is not encoded as a DirectoryString",
+		},
+	}
+
+	for file, expected := range tests {
+		der, err := readCSR("./testdata/csr/" + file)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		result := doCSR(der)
+
+		for _, exp := range expected {
+			var found bool
+			for _, e := range result.Errors.List() {
+				if strings.HasPrefix(e.Error(), exp) {
+					found = true
+					if len(e.Check()) == 0 {
+						t.Errorf("Expected a check name for '%s' in %s", e.Error(), file)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("Expected '%s' in %s, got %v", exp, file, result.Errors.List())
+			}
+		}
+	}
+
+	if _, err := readCSR("./testdata/uppercasesan.pem"); err == nil {
+		t.Error("Expected an error reading a certificate as CSR")
+	}
+}
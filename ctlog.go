@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/weyhmueller/certlint/errors"
+
+	ct "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/client"
+	"github.com/google/certificate-transparency-go/jsonclient"
+)
+
+// ctBatchSize is the number of entries requested from a CT log at once, a log
+// can return less entries than requested
+const ctBatchSize = 256
+
+// doCTLog queues the certificates and precertificates in the entries start up
+// to and including end of a CT log, until the tree head when end is negative.
+// The log and index of the entry are included in the results.
+func doCTLog(uri string, start, end int64) {
+	defer close(jobs)
+
+	logClient, err := client.New(uri, &http.Client{Timeout: 30 * time.Second}, jsonclient.Options{})
+	if err != nil {
+		fmt.Printf("Failed to create log client: %s\n", err.Error())
+		return
+	}
+
+	if end < 0 {
+		sth, err := logClient.GetSTH(context.Background())
+		if err != nil {
+			fmt.Printf("Failed to get tree head: %s\n", err.Error())
+			return
+		}
+		end = int64(sth.TreeSize) - 1
+	}
+
+	for index := start; index <= end; {
+		last := index + ctBatchSize - 1
+		if last > end {
+			last = end
+		}
+
+		resp, err := logClient.GetRawEntries(context.Background(), index, last)
+		if err != nil {
+			fmt.Printf("Failed to get entries: %s\n", err.Error())
+			break
+		}
+		if len(resp.Entries) == 0 {
+			break
+		}
+
+		for i := range resp.Entries {
+			source := fmt.Sprintf("%s#%d", uri, index)
+
+			// The certificate of a precertificate entry is the precertificate
+			// including the poison extension
+			entry, err := ct.RawLogEntryFromLeaf(index, &resp.Entries[i])
+			index++
+			if err != nil {
+				var e = errors.New(nil)
+				e.Err("Failed to parse log entry: %s", err.Error())
+				notePriority(e)
+				results <- testResult{Source: source, Errors: e}
+				continue
+			}
+
+			count++
+			jobs <- bulkJob{der: entry.Cert.Data, source: source}
+		}
+	}
+
+	fmt.Printf("Checked %d certificates\n", count)
+}
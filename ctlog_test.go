@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// ctVector returns b prefixed with its 24 bit length
+func ctVector(b []byte) []byte {
+	return append([]byte{byte(len(b) >> 16), byte(len(b) >> 8), byte(len(b))}, b...)
+}
+
+// ctEntry returns the leaf input and extra data of a log entry, issuer is
+// included as the chain
+func ctEntry(der, issuer []byte, precert bool) (leaf, extra []byte) {
+	leaf = make([]byte, 12)
+	if precert {
+		leaf[11] = 1
+		leaf = append(leaf, make([]byte, 32)...)
+		leaf = append(leaf, ctVector([]byte{0x30, 0x00})...)
+		extra = ctVector(der)
+	} else {
+		leaf = append(leaf, ctVector(der)...)
+	}
+	leaf = append(leaf, 0, 0)
+	extra = append(extra, ctVector(ctVector(issuer))...)
+	return leaf, extra
+}
+
+func TestDoCTLog(t *testing.T) {
+	defer func(j chan bulkJob, r chan testResult, c int64) { jobs, results, count = j, r, c }(jobs, results, count)
+	jobs = make(chan bulkJob, 100)
+	results = make(chan testResult, 100)
+
+	type entry struct {
+		LeafInput []byte `json:"leaf_input"`
+		ExtraData []byte `json:"extra_data"`
+	}
+	issuer := getCertificate("./testdata/bundle/complete.pem")
+	var entries []entry
+	for _, e := range []struct {
+		file    string
+		precert bool
+	}{
+		{"./testdata/nokeyusage.pem", false},
+		{"./testdata/precert.pem", true},
+		{"./testdata/precertfinal.pem", false},
+	} {
+		leaf, extra := ctEntry(getCertificate(e.file), issuer, e.precert)
+		entries = append(entries, entry{leaf, extra})
+	}
+	entries = append(entries, entry{LeafInput: []byte{0}})
+
+	// The log returns at most two entries at once
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ct/v1/get-sth":
+			// An unsigned STH, the client only verifies the signature with a
+			// known log key
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"tree_size":           len(entries),
+				"timestamp":           1,
+				"sha256_root_hash":    make([]byte, 32),
+				"tree_head_signature": []byte{4, 3, 0, 2, 0x30, 0x00},
+			})
+		case "/ct/v1/get-entries":
+			start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+			end, _ := strconv.Atoi(r.URL.Query().Get("end"))
+			if end > start+1 {
+				end = start + 1
+			}
+			json.NewEncoder(w).Encode(map[string][]entry{"entries": entries[start : end+1]})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	doCTLog(ts.URL, 1, -1)
+
+	var sources []string
+	for job := range jobs {
+		sources = append(sources, job.source)
+
+		// The precertificate is checked instead of the TBSCertificate
+		if job.source == ts.URL+"#1" && !bytes.Equal(job.der, getCertificate("./testdata/precert.pem")) {
+			t.Error("Expected the precertificate for entry 1")
+		}
+	}
+	if len(sources) != 2 || sources[0] != ts.URL+"#1" || sources[1] != ts.URL+"#2" {
+		t.Errorf("Expected entries 1 and 2, got %v", sources)
+	}
+
+	// The invalid last entry is reported directly
+	select {
+	case r := <-results:
+		if r.Source != ts.URL+"#3" || r.Errors.Priority() != errors.Error {
+			t.Errorf("Expected an error for entry 3, got %v", r.Errors.List())
+		}
+	default:
+		t.Error("Expected a result for the invalid entry")
+	}
+}
@@ -0,0 +1,180 @@
+package ctlogs
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	_ "embed" // bundled log list
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+//go:generate curl -sSfo log_list.json https://www.gstatic.com/ct/log_list/v3/log_list.json
+
+// bundledLogList is the snapshot of the CT log list used when no other list is
+// loaded, refresh it with go generate
+//
+//go:embed log_list.json
+var bundledLogList []byte
+
+// States of a log in the log list
+const (
+	Pending   = "pending"
+	Qualified = "qualified"
+	Usable    = "usable"
+	ReadOnly  = "readonly"
+	Retired   = "retired"
+	Rejected  = "rejected"
+)
+
+// Log is a CT log of the log list
+type Log struct {
+	Description string
+	Operator    string
+	URL         string
+	ID          [sha256.Size]byte
+	Key         crypto.PublicKey
+	// State is the current state of the log, e.g. Usable or Retired
+	State string
+	// StateSince is the time the log entered its current state
+	StateSince time.Time
+}
+
+// LogList contains the known CT logs by log ID
+type LogList struct {
+	Timestamp time.Time
+	logs      map[[sha256.Size]byte]*Log
+}
+
+// jsonLogList is the v3 log list format of
+// https://www.gstatic.com/ct/log_list/v3/log_list_schema.json
+type jsonLogList struct {
+	Timestamp time.Time `json:"log_list_timestamp"`
+	Operators []struct {
+		Name string `json:"name"`
+		Logs []struct {
+			Description string `json:"description"`
+			LogID       []byte `json:"log_id"`
+			Key         []byte `json:"key"`
+			URL         string `json:"url"`
+			State       map[string]struct {
+				Timestamp time.Time `json:"timestamp"`
+			} `json:"state"`
+		} `json:"logs"`
+	} `json:"operators"`
+}
+
+// Parse parses a log list in the v3 JSON format
+func Parse(b []byte) (*LogList, error) {
+	var j jsonLogList
+	if err := json.Unmarshal(b, &j); err != nil {
+		return nil, err
+	}
+
+	l := &LogList{
+		Timestamp: j.Timestamp,
+		logs:      make(map[[sha256.Size]byte]*Log),
+	}
+	for _, op := range j.Operators {
+		for _, jl := range op.Logs {
+			key, err := x509.ParsePKIXPublicKey(jl.Key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key of log '%s': %s", jl.Description, err)
+			}
+
+			log := &Log{
+				Description: jl.Description,
+				Operator:    op.Name,
+				URL:         jl.URL,
+				ID:          sha256.Sum256(jl.Key),
+				Key:         key,
+			}
+			for state, s := range jl.State {
+				log.State, log.StateSince = state, s.Timestamp
+			}
+			l.logs[log.ID] = log
+		}
+	}
+	return l, nil
+}
+
+// Load reads a log list in the v3 JSON format from a file
+func Load(file string) (*LogList, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(b)
+}
+
+// Fetch downloads a log list in the v3 JSON format, e.g. from
+// https://www.gstatic.com/ct/log_list/v3/log_list.json
+func Fetch(ctx context.Context, url string) (*LogList, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected response '%s'", resp.Status)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(b)
+}
+
+// Len returns the number of logs in the list
+func (l *LogList) Len() int {
+	return len(l.logs)
+}
+
+// Lookup returns the log with the given log ID, nil if the log is unknown
+func (l *LogList) Lookup(id [sha256.Size]byte) *Log {
+	return l.logs[id]
+}
+
+var defaultMutex = &sync.RWMutex{}
+var defaultList *LogList
+
+// Default returns the log list set with SetDefault, or the bundled log list
+func Default() *LogList {
+	defaultMutex.RLock()
+	l := defaultList
+	defaultMutex.RUnlock()
+	if l != nil {
+		return l
+	}
+
+	l, err := Parse(bundledLogList)
+	if err != nil {
+		panic(fmt.Sprintf("invalid bundled log list: %s", err))
+	}
+	defaultMutex.Lock()
+	if defaultList == nil {
+		defaultList = l
+	}
+	l = defaultList
+	defaultMutex.Unlock()
+	return l
+}
+
+// SetDefault replaces the log list used by the checks, e.g. with a more recent
+// list than the bundled list
+func SetDefault(l *LogList) {
+	defaultMutex.Lock()
+	defaultList = l
+	defaultMutex.Unlock()
+}
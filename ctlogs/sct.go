@@ -0,0 +1,217 @@
+package ctlogs
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// SCTListOID is the extension with the embedded SCT list, RFC 6962 §3.3
+var SCTListOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+// Hash and signature algorithms of RFC 5246 §7.4.1.4.1 used by CT logs
+const (
+	hashSHA256     = 4
+	signatureRSA   = 1
+	signatureECDSA = 3
+)
+
+// SCT is a SignedCertificateTimestamp, RFC 6962 §3.2
+type SCT struct {
+	Version            uint8
+	LogID              [sha256.Size]byte
+	Timestamp          uint64
+	Extensions         []byte
+	HashAlgorithm      uint8
+	SignatureAlgorithm uint8
+	Signature          []byte
+}
+
+// Time returns the timestamp of the SCT
+func (s SCT) Time() time.Time {
+	return time.Unix(0, int64(s.Timestamp)*int64(time.Millisecond)).UTC()
+}
+
+// reader reads the TLS encoded vectors of RFC 5246 §4
+type reader struct {
+	b   []byte
+	err error
+}
+
+// next returns the next n bytes
+func (r *reader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > len(r.b) {
+		r.err = fmt.Errorf("truncated data")
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+// uint reads an unsigned integer of n bytes
+func (r *reader) uint(n int) uint64 {
+	var v uint64
+	for _, c := range r.next(n) {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+// vector reads a vector with a length prefix of n bytes
+func (r *reader) vector(n int) []byte {
+	return r.next(int(r.uint(n)))
+}
+
+// ParseSCTList parses the value of the embedded SCT list extension
+func ParseSCTList(value []byte) ([]SCT, error) {
+	var list []byte
+	rest, err := asn1.Unmarshal(value, &list)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("trailing data after SCT list")
+	}
+
+	lr := &reader{b: list}
+	r := &reader{b: lr.vector(2)}
+	if lr.err != nil {
+		return nil, lr.err
+	}
+	if len(lr.b) > 0 {
+		return nil, fmt.Errorf("trailing data after SCT list")
+	}
+
+	var scts []SCT
+	for r.err == nil && len(r.b) > 0 {
+		sr := &reader{b: r.vector(2)}
+		var s SCT
+		s.Version = uint8(sr.uint(1))
+		copy(s.LogID[:], sr.next(sha256.Size))
+		s.Timestamp = sr.uint(8)
+		s.Extensions = sr.vector(2)
+		s.HashAlgorithm = uint8(sr.uint(1))
+		s.SignatureAlgorithm = uint8(sr.uint(1))
+		s.Signature = sr.vector(2)
+		if sr.err == nil && len(sr.b) > 0 {
+			sr.err = fmt.Errorf("trailing data after SCT")
+		}
+		if sr.err != nil {
+			return nil, sr.err
+		}
+		scts = append(scts, s)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return scts, nil
+}
+
+// EmbeddedSCTs returns the SCTs embedded in cert, nil if cert contains no SCT
+// list
+func EmbeddedSCTs(cert *x509.Certificate) ([]SCT, error) {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(SCTListOID) {
+			return ParseSCTList(ext.Value)
+		}
+	}
+	return nil, nil
+}
+
+// VerifyEmbedded verifies the signature of log on an SCT embedded in cert,
+// issuer is the certificate that signed cert.
+func VerifyEmbedded(s SCT, log *Log, cert, issuer *x509.Certificate) error {
+	if s.Version != 0 {
+		return fmt.Errorf("unsupported SCT version %d", s.Version)
+	}
+	if s.HashAlgorithm != hashSHA256 {
+		return fmt.Errorf("unsupported hash algorithm %d", s.HashAlgorithm)
+	}
+
+	tbs, err := precertTBS(cert.RawTBSCertificate)
+	if err != nil {
+		return err
+	}
+	if len(tbs) > 1<<24-1 {
+		return fmt.Errorf("TBSCertificate too large")
+	}
+
+	// digitally-signed struct of the precert entry, RFC 6962 §3.2
+	var b bytes.Buffer
+	b.WriteByte(s.Version)
+	b.WriteByte(0) // certificate_timestamp
+	binary.Write(&b, binary.BigEndian, s.Timestamp)
+	binary.Write(&b, binary.BigEndian, uint16(1)) // precert_entry
+	keyHash := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	b.Write(keyHash[:])
+	b.Write([]byte{byte(len(tbs) >> 16), byte(len(tbs) >> 8), byte(len(tbs))})
+	b.Write(tbs)
+	binary.Write(&b, binary.BigEndian, uint16(len(s.Extensions)))
+	b.Write(s.Extensions)
+
+	digest := sha256.Sum256(b.Bytes())
+	switch key := log.Key.(type) {
+	case *ecdsa.PublicKey:
+		if s.SignatureAlgorithm != signatureECDSA {
+			return fmt.Errorf("signature algorithm %d does not match the ECDSA log key", s.SignatureAlgorithm)
+		}
+		if !ecdsa.VerifyASN1(key, digest[:], s.Signature) {
+			return fmt.Errorf("ECDSA verification failure")
+		}
+	case *rsa.PublicKey:
+		if s.SignatureAlgorithm != signatureRSA {
+			return fmt.Errorf("signature algorithm %d does not match the RSA log key", s.SignatureAlgorithm)
+		}
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], s.Signature); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported log key %T", log.Key)
+	}
+	return nil
+}
+
+// tbsCertificate is a TBSCertificate with the fields that are not changed in
+// their DER encoding
+type tbsCertificate struct {
+	Version            int `asn1:"optional,explicit,default:0,tag:0"`
+	SerialNumber       asn1.RawValue
+	SignatureAlgorithm asn1.RawValue
+	Issuer             asn1.RawValue
+	Validity           asn1.RawValue
+	Subject            asn1.RawValue
+	PublicKey          asn1.RawValue
+	UniqueID           asn1.BitString   `asn1:"optional,tag:1"`
+	SubjectUniqueID    asn1.BitString   `asn1:"optional,tag:2"`
+	Extensions         []pkix.Extension `asn1:"optional,explicit,tag:3"`
+}
+
+// precertTBS returns the DER encoded TBSCertificate without the SCT list, as
+// signed by the log
+func precertTBS(raw []byte) ([]byte, error) {
+	var tbs tbsCertificate
+	if _, err := asn1.Unmarshal(raw, &tbs); err != nil {
+		return nil, err
+	}
+
+	var exts []pkix.Extension
+	for _, ext := range tbs.Extensions {
+		if !ext.Id.Equal(SCTListOID) {
+			exts = append(exts, ext)
+		}
+	}
+	tbs.Extensions = exts
+	return asn1.Marshal(tbs)
+}
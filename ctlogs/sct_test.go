@@ -0,0 +1,133 @@
+package ctlogs
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+// The test certificates and log key are the test data of
+// github.com/google/certificate-transparency-go
+
+func TestBundledLogList(t *testing.T) {
+	l, err := Parse(bundledLogList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Len() == 0 {
+		t.Error("The bundled log list contains no logs, refresh it with go generate")
+	}
+}
+
+func readPEM(t *testing.T, file string) []byte {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		t.Fatalf("No PEM block in %s", file)
+	}
+	return block.Bytes
+}
+
+func readCert(t *testing.T, file string) *x509.Certificate {
+	c, err := x509.ParseCertificate(readPEM(t, file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// testLogList returns a log list with the test log in the given state
+func testLogList(t *testing.T, state string, since time.Time) *LogList {
+	key := base64.StdEncoding.EncodeToString(readPEM(t, "../testdata/sct/log.pem"))
+	l, err := Parse([]byte(fmt.Sprintf(`{
+		"log_list_timestamp": "2020-01-01T00:00:00Z",
+		"operators": [{
+			"name": "Test",
+			"logs": [{
+				"description": "Test log",
+				"log_id": "3xwuwRUAlFJHqWFoMl3cXHlZ6PfG04j8AC4LvT9012Q=",
+				"key": "%s",
+				"url": "https://ct.example.com/",
+				"state": {"%s": {"timestamp": "%s"}}
+			}]
+		}]
+	}`, key, state, since.Format(time.RFC3339))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestParseSCTList(t *testing.T) {
+	cert := readCert(t, "../testdata/sct/embedded.pem")
+	scts, err := EmbeddedSCTs(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scts) != 1 {
+		t.Fatalf("Expected 1 SCT, got %d", len(scts))
+	}
+
+	l := testLogList(t, Usable, time.Time{})
+	log := l.Lookup(scts[0].LogID)
+	if log == nil {
+		t.Fatal("Log of the SCT not found")
+	}
+	if log.Description != "Test log" || log.Operator != "Test" || log.State != Usable {
+		t.Errorf("Unexpected log %+v", log)
+	}
+	if want := time.Date(2013, 4, 5, 17, 4, 16, 275000000, time.UTC); !scts[0].Time().Equal(want) {
+		t.Errorf("Expected timestamp %s, got %s", want, scts[0].Time())
+	}
+
+	for _, b := range [][]byte{{0x04, 0x00}, {0x04, 0x02, 0x00, 0x05}, {0x04, 0x04, 0x00, 0x02, 0x00, 0x01}} {
+		if _, err := ParseSCTList(b); err == nil {
+			t.Errorf("Expected an error parsing %x", b)
+		}
+	}
+}
+
+func TestVerifyEmbedded(t *testing.T) {
+	issuer := readCert(t, "../testdata/sct/ca.pem")
+	l := testLogList(t, Usable, time.Time{})
+
+	tests := map[string]bool{
+		"../testdata/sct/embedded.pem": true,
+		"../testdata/sct/invalid.pem":  false,
+	}
+	for file, valid := range tests {
+		cert := readCert(t, file)
+		scts, err := EmbeddedSCTs(cert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range scts {
+			err := VerifyEmbedded(s, l.Lookup(s.LogID), cert, issuer)
+			if valid && err != nil {
+				t.Errorf("Unexpected error verifying the SCT of %s: %s", file, err)
+			} else if !valid && err == nil {
+				t.Errorf("Expected an invalid SCT signature in %s", file)
+			}
+		}
+	}
+}
+
+func TestDefault(t *testing.T) {
+	if Default() == nil {
+		t.Fatal("No bundled log list")
+	}
+
+	l := testLogList(t, Retired, time.Now())
+	SetDefault(l)
+	defer SetDefault(nil)
+	if Default() != l {
+		t.Error("Log list not replaced")
+	}
+}
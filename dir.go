@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// defaultGlobs are the file name patterns of certificate files in a directory
+const defaultGlobs = "*.pem,*.crt,*.cer,*.der,*.p7b"
+
+// parseGlobs parses a comma separated list of file name patterns
+func parseGlobs(s string) ([]string, error) {
+	var globs []string
+	for _, g := range strings.Split(s, ",") {
+		g = strings.TrimSpace(g)
+		if len(g) == 0 {
+			continue
+		}
+		if _, err := filepath.Match(g, ""); err != nil {
+			return nil, fmt.Errorf("Invalid glob '%s': %s", g, err.Error())
+		}
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+// matchGlobs reports if the file name of path matches one of the globs
+func matchGlobs(path string, globs []string) bool {
+	name := filepath.Base(path)
+	for _, g := range globs {
+		if ok, _ := filepath.Match(g, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// doDir walks the directory tree and queues all certificates in the files
+// matching the globs.
+func doDir(dir string, globs []string) {
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Report unreadable directories and files but continue the walk
+			fmt.Fprintln(os.Stderr, err)
+			return nil
+		}
+		if info.IsDir() || !matchGlobs(path, globs) {
+			return nil
+		}
+
+		return queueFile(path)
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	fmt.Printf("Checked %d certificates\n", count)
+	close(jobs)
+}
+
+// queueFile queues all certificates in a file, the path of the file is
+// included in the results.
+func queueFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nil
+	}
+	defer f.Close()
+
+	return scanCertificates(f, func(der, pemCert []byte, err error) {
+		if der != nil {
+			count++
+			jobs <- bulkJob{der: der, source: path}
+			return
+		}
+
+		var e = errors.New(nil)
+		if err != nil {
+			e.Err("Failed to parse PKCS#7 bundle: %s", err)
+		}
+		notePriority(e)
+		results <- testResult{
+			Pem:    string(pemCert),
+			Source: path,
+			Errors: e,
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGlobs(t *testing.T) {
+	globs, err := parseGlobs(defaultGlobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, match := range map[string]bool{
+		"/etc/ssl/certs/ca.pem": true,
+		"server.CRT":            false,
+		"secrets/tls.crt":       true,
+		"key.key":               false,
+	} {
+		if matchGlobs(path, globs) != match {
+			t.Errorf("Expected match %t for %s", match, path)
+		}
+	}
+
+	if _, err = parseGlobs("*.pem,[a-"); err == nil {
+		t.Error("Expected an error for an invalid glob")
+	}
+}
+
+func TestDoDir(t *testing.T) {
+	defer func(j chan bulkJob, c int64) { jobs, count = j, c }(jobs, count)
+	jobs = make(chan bulkJob, 100)
+
+	doDir("./testdata/bundle", []string{"*.pem", "*.p7b"})
+
+	sources := make(map[string]int)
+	for job := range jobs {
+		sources[job.source]++
+	}
+
+	expected := map[string]int{
+		filepath.Join("testdata", "bundle", "complete.pem"):   3,
+		filepath.Join("testdata", "bundle", "incomplete.pem"): 2,
+		filepath.Join("testdata", "bundle", "complete.p7b"):   3,
+	}
+	for source, n := range expected {
+		if sources[source] != n {
+			t.Errorf("Expected %d certificates from %s, got %d", n, source, sources[source])
+		}
+	}
+	if len(sources) != len(expected) {
+		t.Errorf("Unexpected sources %v", sources)
+	}
+}
@@ -0,0 +1,35 @@
+package errors
+
+import (
+	"fmt"
+	"sync"
+)
+
+var codeMutex = &sync.RWMutex{}
+
+// codes contains the stable code per message format
+var codes = make(map[string]string)
+
+// RegisterCodes registers the stable codes of findings by their message
+// format, e.g. "Certificate has no key usage set": "CERTLINT_KU_001". The code
+// of a finding never changes, when the message is reworded the new format must
+// be registered with the old code. Registering another code for a known format
+// panics.
+func RegisterCodes(c map[string]string) {
+	codeMutex.Lock()
+	defer codeMutex.Unlock()
+	for format, code := range c {
+		if old, ok := codes[format]; ok && old != code {
+			panic(fmt.Sprintf("errors: format %q registered as %s and %s", format, old, code))
+		}
+		codes[format] = code
+	}
+}
+
+// Code returns the code registered for a message format, or an empty string
+// if no code has been registered.
+func Code(format string) string {
+	codeMutex.RLock()
+	defer codeMutex.RUnlock()
+	return codes[format]
+}
@@ -2,6 +2,7 @@ package errors
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 )
 
@@ -23,15 +24,31 @@ const (
 	Emergency
 )
 
-// Config defines the error configuration, currently no configuration options
-// are available.
+// ParsePriority returns the priority with the given name, the name is not case
+// sensitive.
+func ParsePriority(name string) (Priority, error) {
+	for p := Debug; p <= Emergency; p++ {
+		if strings.EqualFold(p.String(), name) {
+			return p, nil
+		}
+	}
+	return Unknown, fmt.Errorf("unknown severity %s", name)
+}
+
+// Config defines the error configuration
 type Config struct {
+	// Deduplicate collapses identical errors into a single error with the
+	// number of occurrences as count.
+	Deduplicate bool
 }
 
 // Err contains a single error
 type Err struct {
-	p   Priority
-	msg string
+	p     Priority
+	msg   string
+	check string
+	code  string
+	count int
 }
 
 // Priority returns the priority of this error
@@ -39,6 +56,25 @@ func (e Err) Priority() Priority {
 	return e.p
 }
 
+// Check returns the name of the check that reported this error, if known
+func (e Err) Check() string {
+	return e.check
+}
+
+// Code returns the stable code of this error, if registered with RegisterCodes
+func (e Err) Code() string {
+	return e.code
+}
+
+// Count returns how often this error occurred, errors are only counted more
+// than once when deduplicated.
+func (e Err) Count() int {
+	if e.count < 1 {
+		return 1
+	}
+	return e.count
+}
+
 // String returns the message of this error
 func (e Err) Error() string {
 	return e.msg
@@ -89,6 +125,41 @@ func (e *Errors) List(p ...Priority) []Err {
 	return l
 }
 
+// AtLeast returns the errors with at least the given priority
+func (e *Errors) AtLeast(p Priority) *Errors {
+	if e == nil {
+		return nil
+	}
+
+	l := New(e.config)
+	for _, err := range e.err {
+		if err.p >= p {
+			l.err = append(l.err, err)
+			if err.p > l.p {
+				l.p = err.p
+			}
+		}
+	}
+	return l
+}
+
+// Map returns the errors with the priority returned by fn for each error
+func (e *Errors) Map(fn func(Err) Priority) *Errors {
+	if e == nil {
+		return nil
+	}
+
+	l := New(e.config)
+	for _, err := range e.err {
+		err.p = fn(err)
+		l.err = append(l.err, err)
+		if err.p > l.p {
+			l.p = err.p
+		}
+	}
+	return l
+}
+
 // Append add all Errors to existing Errors
 func (e *Errors) Append(err *Errors) error {
 	if err == nil {
@@ -98,7 +169,9 @@ func (e *Errors) Append(err *Errors) error {
 	e.m.Lock()
 
 	// append Errors at the end of the current list
-	e.err = append(e.err, err.err...)
+	for _, r := range err.err {
+		e.insert(r)
+	}
 
 	// set highest priority
 	if err.p > e.p {
@@ -109,6 +182,22 @@ func (e *Errors) Append(err *Errors) error {
 	return nil
 }
 
+// SetCheck sets the name of the check that reported the errors, errors that
+// already have a check name are left untouched.
+func (e *Errors) SetCheck(name string) {
+	if e == nil {
+		return
+	}
+
+	e.m.Lock()
+	for i := range e.err {
+		if len(e.err[i].check) == 0 {
+			e.err[i].check = name
+		}
+	}
+	e.m.Unlock()
+}
+
 // Emerg log an error with severity Emergency
 func (e *Errors) Emerg(format string, a ...interface{}) error {
 	return e.add(Emergency, format, a)
@@ -159,13 +248,15 @@ func (e *Errors) add(p Priority, format string, a []interface{}) error {
 
 	msg := format
 	if len(a) > 0 {
-		msg = fmt.Sprintf(format, a)
+		msg = fmt.Sprintf(format, a...)
 	}
 
 	// add this priority to the end of the list
-	e.err = append(e.err, Err{
-		p:   p,
-		msg: msg,
+	e.insert(Err{
+		p:     p,
+		msg:   msg,
+		code:  Code(format),
+		count: 1,
 	})
 
 	// set highest priority in this list
@@ -176,3 +267,17 @@ func (e *Errors) add(p Priority, format string, a []interface{}) error {
 	e.m.Unlock()
 	return nil
 }
+
+// insert adds an error to the end of the list, or increases the count of an
+// identical error when deduplicating. The caller must hold the lock.
+func (e *Errors) insert(err Err) {
+	if e.config != nil && e.config.Deduplicate {
+		for i, r := range e.err {
+			if r.p == err.p && r.msg == err.msg && r.check == err.check && r.code == err.code {
+				e.err[i].count = r.Count() + err.Count()
+				return
+			}
+		}
+	}
+	e.err = append(e.err, err)
+}
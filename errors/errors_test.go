@@ -1,9 +1,18 @@
 package errors
 
 import (
+	"encoding/json"
 	"testing"
 )
 
+func TestFormat(t *testing.T) {
+	e := New(nil)
+	e.Err("Serial %s issued by %s", "01", "Test CA")
+	if l := e.List(); len(l) != 1 || l[0].Error() != "Serial 01 issued by Test CA" {
+		t.Errorf("Unexpected message got %v, want %s", l, "Serial 01 issued by Test CA")
+	}
+}
+
 func TestErrros(t *testing.T) {
 	e := new(Errors)
 	e.Warning("Warning")
@@ -30,3 +39,160 @@ func TestErrros(t *testing.T) {
 		t.Errorf("Unexpected length got %d, want %d", len(e.List()), 3)
 	}
 }
+
+func TestSetCheck(t *testing.T) {
+	e := New(nil)
+	e.Err("Error")
+	e.SetCheck("First Check")
+
+	e2 := New(nil)
+	e2.Warning("Warning")
+	e.Append(e2)
+	e.SetCheck("Second Check")
+
+	l := e.List()
+	if l[0].Check() != "First Check" {
+		t.Errorf("Unexpected check got %s, want %s", l[0].Check(), "First Check")
+	}
+	if l[1].Check() != "Second Check" {
+		t.Errorf("Unexpected check got %s, want %s", l[1].Check(), "Second Check")
+	}
+}
+
+func TestParsePriority(t *testing.T) {
+	for name, expected := range map[string]Priority{
+		"warning":   Warning,
+		"ERROR":     Error,
+		"Critical":  Critical,
+		"emergency": Emergency,
+	} {
+		p, err := ParsePriority(name)
+		if err != nil {
+			t.Error(err)
+		}
+		if p != expected {
+			t.Errorf("Unexpected priority for %s got %s, want %s", name, p, expected)
+		}
+	}
+
+	if _, err := ParsePriority("unknown"); err == nil {
+		t.Error("Expected an error for an unknown priority")
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	e := New(nil)
+	e.Notice("Notice")
+	e.Err("Error")
+	e.Warning("Warning")
+	e.SetCheck("Check")
+
+	l := e.AtLeast(Warning)
+	if len(l.List()) != 2 || l.List()[0].Error() != "Error" || l.List()[1].Error() != "Warning" {
+		t.Errorf("Unexpected errors %v", l.List())
+	}
+	if l.Priority() != Error {
+		t.Errorf("Unexpected priority got %d, want %d", l.Priority(), Error)
+	}
+	if l.List()[0].Check() != "Check" {
+		t.Errorf("Unexpected check got %s, want %s", l.List()[0].Check(), "Check")
+	}
+
+	if l = e.AtLeast(Critical); len(l.List()) != 0 || l.Priority() != Unknown {
+		t.Errorf("Unexpected errors %v", l.List())
+	}
+}
+
+func TestMap(t *testing.T) {
+	e := New(nil)
+	e.Notice("Notice")
+	e.Err("Error")
+
+	l := e.Map(func(err Err) Priority {
+		if err.Error() == "Error" {
+			return Warning
+		}
+		return err.Priority()
+	})
+	if l.Priority() != Warning || l.List()[1].Priority() != Warning || l.List()[0].Priority() != Notice {
+		t.Errorf("Unexpected priorities %v", l.List())
+	}
+	if e.Priority() != Error {
+		t.Errorf("Unexpected change of the original priority %d", e.Priority())
+	}
+}
+
+func TestCodes(t *testing.T) {
+	RegisterCodes(map[string]string{"Test finding %d": "CERTLINT_TEST_001"})
+
+	e := New(nil)
+	e.Err("Test finding %d", 1)
+	e.Err("Unregistered finding")
+	if l := e.List(); l[0].Code() != "CERTLINT_TEST_001" || l[1].Code() != "" {
+		t.Errorf("Unexpected codes %q and %q", l[0].Code(), l[1].Code())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a conflicting code")
+		}
+	}()
+	RegisterCodes(map[string]string{"Test finding %d": "CERTLINT_TEST_002"})
+}
+
+func TestMarshalJSON(t *testing.T) {
+	RegisterCodes(map[string]string{"JSON finding": "CERTLINT_TEST_003"})
+
+	e := New(nil)
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "[]" {
+		t.Errorf("Unexpected JSON for no errors: %s", b)
+	}
+
+	e.Err("JSON finding")
+	e.SetCheck("Test Check")
+	e.Notice("Other finding")
+	b, err = json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"severity":"ERROR","code":"CERTLINT_TEST_003","message":"JSON finding","check":"Test Check"},{"severity":"NOTICE","message":"Other finding"}]`
+	if string(b) != want {
+		t.Errorf("Unexpected JSON\ngot:  %s\nwant: %s", b, want)
+	}
+}
+
+func TestDeduplicate(t *testing.T) {
+	e := New(&Config{Deduplicate: true})
+	e.Warning("Duplicate")
+	e.Warning("Duplicate")
+	e.Err("Duplicate")
+
+	e2 := New(nil)
+	e2.Warning("Duplicate")
+	e2.Warning("Duplicate")
+	if len(e2.List()) != 2 {
+		t.Errorf("Unexpected length without deduplication got %d, want %d", len(e2.List()), 2)
+	}
+	e.Append(e2)
+
+	l := e.List()
+	if len(l) != 2 {
+		t.Fatalf("Unexpected length got %d, want %d", len(l), 2)
+	}
+	if l[0].Count() != 4 || l[1].Count() != 1 {
+		t.Errorf("Unexpected counts got %d and %d, want 4 and 1", l[0].Count(), l[1].Count())
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"severity":"WARNING","message":"Duplicate","count":4},{"severity":"ERROR","message":"Duplicate"}]`
+	if string(b) != want {
+		t.Errorf("Unexpected JSON\ngot:  %s\nwant: %s", b, want)
+	}
+}
@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// jsonErr is the JSON representation of a single error
+type jsonErr struct {
+	Severity string `json:"severity"`
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message"`
+	Check    string `json:"check,omitempty"`
+	Count    int    `json:"count,omitempty"`
+}
+
+// MarshalJSON encodes the error as an object with the severity, code, message
+// and check name, the count is included when the error occurred more than once.
+func (e Err) MarshalJSON() ([]byte, error) {
+	j := jsonErr{
+		Severity: strings.ToUpper(e.p.String()),
+		Code:     e.code,
+		Message:  e.msg,
+		Check:    e.check,
+	}
+	if e.Count() > 1 {
+		j.Count = e.Count()
+	}
+	return json.Marshal(j)
+}
+
+// MarshalJSON encodes the errors as a list of error objects, an empty list
+// when there are no errors.
+func (e *Errors) MarshalJSON() ([]byte, error) {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	if e.err == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(e.err)
+}
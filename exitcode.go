@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// defaultExitCodes exits with 1 when an error is found and with 2 for a
+// critical or higher finding
+const defaultExitCodes = "error=1,critical=2"
+
+// failureExitCode is the exit code when the options, input files or report
+// can't be used, EX_USAGE of sysexits.h
+const failureExitCode = 64
+
+// exitCode is the exit code of the process when a finding of at least the
+// severity is reported
+type exitCode struct {
+	Severity errors.Priority
+	Code     int
+}
+
+// highest is the highest severity of all findings reported so far, failed is
+// set when the run could not be completed
+var highest struct {
+	sync.Mutex
+	p      errors.Priority
+	failed bool
+}
+
+// parseExitCodes parses a comma separated list of severity=code mappings, the
+// mappings are returned from the highest to the lowest severity.
+func parseExitCodes(s string) ([]exitCode, error) {
+	var codes []exitCode
+	for _, m := range strings.Split(s, ",") {
+		if len(strings.TrimSpace(m)) == 0 {
+			continue
+		}
+
+		parts := strings.SplitN(m, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid exit code mapping %s, use severity=code", m)
+		}
+		p, err := errors.ParsePriority(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, err
+		}
+		code, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || code < 0 || code > 125 {
+			return nil, fmt.Errorf("invalid exit code %s for %s", parts[1], parts[0])
+		}
+		codes = append(codes, exitCode{p, code})
+	}
+
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i].Severity > codes[j].Severity
+	})
+	return codes, nil
+}
+
+// notePriority records the highest severity of the findings
+func notePriority(e *errors.Errors) {
+	if e == nil {
+		return
+	}
+
+	highest.Lock()
+	if e.Priority() > highest.p {
+		highest.p = e.Priority()
+	}
+	highest.Unlock()
+}
+
+// reportFindings applies the severity overrides and -min-severity to findings
+// reported outside of linting a certificate, e.g. the aggregates of a bulk run,
+// and records their severity for the exit code.
+func reportFindings(e *errors.Errors) *errors.Errors {
+	e = overrideSeverity(e).AtLeast(minSeverity)
+	notePriority(e)
+	return e
+}
+
+// fail outputs why the run could not be completed and makes the process exit
+// with failureExitCode
+func fail(a ...interface{}) {
+	fmt.Println(a...)
+
+	highest.Lock()
+	highest.failed = true
+	highest.Unlock()
+}
+
+// exitStatus returns the exit code for the highest severity of all findings,
+// or failureExitCode when the run failed
+func exitStatus(codes []exitCode) int {
+	highest.Lock()
+	defer highest.Unlock()
+
+	if highest.failed {
+		return failureExitCode
+	}
+
+	for _, c := range codes {
+		if highest.p >= c.Severity {
+			return c.Code
+		}
+	}
+	return 0
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+func TestParseExitCodes(t *testing.T) {
+	codes, err := parseExitCodes(defaultExitCodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 2 || codes[0] != (exitCode{errors.Critical, 2}) || codes[1] != (exitCode{errors.Error, 1}) {
+		t.Errorf("Unexpected exit codes %v", codes)
+	}
+
+	if codes, err = parseExitCodes(""); err != nil || len(codes) != 0 {
+		t.Errorf("Expected no exit codes, got %v (%v)", codes, err)
+	}
+
+	for _, s := range []string{"error", "error=one", "fatal=1", "error=256"} {
+		if _, err := parseExitCodes(s); err == nil {
+			t.Errorf("Expected an error for %s", s)
+		}
+	}
+}
+
+func TestExitStatus(t *testing.T) {
+	defer func(p errors.Priority) { highest.p = p }(highest.p)
+	highest.p = errors.Unknown
+
+	codes, _ := parseExitCodes("warning=3,critical=2,error=1")
+	for _, c := range []struct {
+		p    errors.Priority
+		code int
+	}{
+		{errors.Notice, 0},
+		{errors.Warning, 3},
+		{errors.Error, 1},
+		{errors.Emergency, 2},
+	} {
+		e := errors.New(nil)
+		switch c.p {
+		case errors.Notice:
+			e.Notice("notice")
+		case errors.Warning:
+			e.Warning("warning")
+		case errors.Error:
+			e.Err("error")
+		case errors.Emergency:
+			e.Emerg("emergency")
+		}
+		notePriority(e)
+
+		if code := exitStatus(codes); code != c.code {
+			t.Errorf("Expected exit code %d after %s, got %d", c.code, c.p, code)
+		}
+	}
+}
+
+// findingAggregate reports a fixed finding over all certificates
+type findingAggregate struct{}
+
+func (findingAggregate) Add(r testResult) {}
+
+func (findingAggregate) Check() *errors.Errors {
+	e := errors.New(nil)
+	e.Err("aggregate error")
+	return e
+}
+
+func TestExitStatusAggregates(t *testing.T) {
+	defer func(p errors.Priority) { highest.p = p }(highest.p)
+	codes, _ := parseExitCodes(defaultExitCodes)
+
+	// Findings below -min-severity don't change the exit code
+	highest.p = errors.Unknown
+	minSeverity = errors.Critical
+	checkAggregates([]aggregate{findingAggregate{}})
+	minSeverity = errors.Unknown
+	if code := exitStatus(codes); code != 0 {
+		t.Errorf("Expected exit code 0, got %d", code)
+	}
+
+	if summary := checkAggregates([]aggregate{findingAggregate{}}); len(summary.List()) != 1 {
+		t.Errorf("Expected the aggregate finding, got %v", summary.List())
+	}
+	if code := exitStatus(codes); code != 1 {
+		t.Errorf("Expected exit code 1 after an aggregate error, got %d", code)
+	}
+}
+
+func TestExitStatusFailure(t *testing.T) {
+	defer func(p errors.Priority) { highest.p, highest.failed = p, false }(highest.p)
+	highest.p = errors.Unknown
+
+	fail("failure")
+	if code := exitStatus(nil); code != failureExitCode {
+		t.Errorf("Expected exit code %d after a failure, got %d", failureExitCode, code)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/weyhmueller/certlint/api"
+
+	"github.com/golang/groupcache/lru"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative api/certlint.proto
+
+// grpcServer implements the CertLint gRPC service, defined in
+// api/certlint.proto.
+type grpcServer struct {
+	api.UnimplementedCertLintServer
+	exp bool
+}
+
+// serveGRPC serves the CertLint gRPC service on the listener until it is
+// closed, expired certificates are always checked when exp is set.
+func serveGRPC(l net.Listener, exp bool) error {
+	s := grpc.NewServer()
+	api.RegisterCertLintServer(s, &grpcServer{exp: exp})
+	return s.Serve(l)
+}
+
+// Lint checks a single certificate
+func (s *grpcServer) Lint(ctx context.Context, req *api.LintRequest) (*api.LintResponse, error) {
+	if len(req.Cert) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Request contains no certificate")
+	}
+
+	var resp *api.LintResponse
+	err := runContext(ctx, func() {
+		resp = s.lint(ctx, nil, nil, req.Id, req.Cert, req.Expired)
+	})
+	return resp, err
+}
+
+// LintChain checks all certificates of a chain, using the chain to find the
+// issuers
+func (s *grpcServer) LintChain(ctx context.Context, req *api.LintChainRequest) (*api.LintChainResponse, error) {
+	if len(req.Certs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Request contains no certificates")
+	}
+
+	resp := &api.LintChainResponse{Id: req.Id}
+	err := runContext(ctx, func() {
+		bundle := newChainBundle(req.Certs)
+		for _, der := range req.Certs {
+			resp.Results = append(resp.Results, s.lint(ctx, nil, bundle, req.Id, der, req.Expired))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// LintBulk checks a stream of certificates, a request without a certificate
+// results in a response with an error instead of ending the stream.
+func (s *grpcServer) LintBulk(stream api.CertLint_LintBulkServer) error {
+	// The lru cache is not safe for concurrent use, use one per stream
+	var icaCache = lru.New(200)
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp := &api.LintResponse{Id: req.Id, Error: "Request contains no certificate"}
+		if len(req.Cert) > 0 {
+			err = runContext(stream.Context(), func() {
+				resp = s.lint(stream.Context(), icaCache, nil, req.Id, req.Cert, req.Expired)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// lint checks the certificate and converts the result in a response
+func (s *grpcServer) lint(ctx context.Context, icaCache *lru.Cache, bundle *certBundle, id string, der []byte, exp bool) *api.LintResponse {
+	result := lintBundle(ctx, icaCache, der, "", nil, bundle, s.exp || exp, true)
+
+	resp := &api.LintResponse{
+		Id:         id,
+		Type:       result.Type,
+		CaOperator: caOperator(result.Cert),
+		Trusted:    result.Trusted,
+	}
+	for _, err := range result.Errors.List() {
+		resp.Findings = append(resp.Findings, &api.Finding{
+			Severity: strings.ToUpper(err.Priority().String()),
+			Check:    err.Check(),
+			Message:  err.Error(),
+		})
+	}
+	return resp
+}
+
+// runContext runs fn and waits until it returns, or returns the status of the
+// context when the deadline expires or the call is canceled first.
+func runContext(ctx context.Context, fn func()) error {
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/weyhmueller/certlint/api"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeBulkStream replays the requests and collects the responses of a
+// LintBulk stream
+type fakeBulkStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	requests  []*api.LintRequest
+	responses []*api.LintResponse
+}
+
+func (f *fakeBulkStream) Context() context.Context { return f.ctx }
+
+func (f *fakeBulkStream) Recv() (*api.LintRequest, error) {
+	if len(f.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := f.requests[0]
+	f.requests = f.requests[1:]
+	return req, nil
+}
+
+func (f *fakeBulkStream) Send(resp *api.LintResponse) error {
+	f.responses = append(f.responses, resp)
+	return nil
+}
+
+func TestGRPCLint(t *testing.T) {
+	s := &grpcServer{}
+
+	resp, err := s.Lint(context.Background(), &api.LintRequest{Id: "1", Cert: getCertificate("./testdata/nokeyusage.pem"), Expired: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Id != "1" || len(resp.Findings) == 0 {
+		t.Errorf("Expected findings for request 1, got %v", resp)
+	}
+	for _, f := range resp.Findings {
+		if len(f.Severity) == 0 || len(f.Message) == 0 {
+			t.Errorf("Incomplete finding %v", f)
+		}
+	}
+
+	if _, err = s.Lint(context.Background(), &api.LintRequest{Id: "2"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without a certificate, got %v", err)
+	}
+}
+
+func TestGRPCLintChain(t *testing.T) {
+	f, err := os.Open("testdata/bundle/complete.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	req := &api.LintChainRequest{Id: "chain", Expired: true}
+	scanCertificates(f, func(der, pemCert []byte, err error) {
+		req.Certs = append(req.Certs, der)
+	})
+
+	resp, err := (&grpcServer{}).LintChain(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Id != "chain" || len(resp.Results) != len(req.Certs) {
+		t.Errorf("Expected %d results, got %d", len(req.Certs), len(resp.Results))
+	}
+}
+
+func TestGRPCLintBulk(t *testing.T) {
+	stream := &fakeBulkStream{
+		ctx: context.Background(),
+		requests: []*api.LintRequest{
+			{Id: "1", Cert: getCertificate("./testdata/nokeyusage.pem")},
+			{Id: "2"},
+		},
+	}
+	if err := (&grpcServer{exp: true}).LintBulk(stream); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stream.responses) != 2 {
+		t.Fatalf("Expected 2 responses, got %d", len(stream.responses))
+	}
+	if stream.responses[0].Id != "1" || len(stream.responses[0].Findings) == 0 {
+		t.Errorf("Expected findings for request 1, got %v", stream.responses[0])
+	}
+	if stream.responses[1].Id != "2" || len(stream.responses[1].Error) == 0 {
+		t.Errorf("Expected an error for request 2, got %v", stream.responses[1])
+	}
+}
+
+func TestRunContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	block := make(chan struct{})
+	defer close(block)
+	if err := runContext(ctx, func() { <-block }); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+
+	if err := runContext(context.Background(), func() {}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// htmlFinding is a single row in the findings table of the HTML report
+type htmlFinding struct {
+	Number     int
+	Issuer     string
+	CAOperator string
+	CN         string
+	Serial     string
+	Type       string
+	Severity   string
+	Message    string
+	Source     string
+}
+
+// htmlIssuer contains the summary of all certificates of a single issuer
+type htmlIssuer struct {
+	Issuer       string
+	Certificates int
+	Errors       int
+	Warnings     int
+	Notices      int
+	Infos        int
+}
+
+type htmlReport struct {
+	Certificates int
+	Issuers      []*htmlIssuer
+	Findings     []htmlFinding
+	Summary      []htmlFinding
+}
+
+// saveHTML writes all results as a single self-contained HTML report
+func saveHTML(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return writeHTML(file, results)
+}
+
+func writeHTML(w io.Writer, results <-chan testResult) error {
+	var report htmlReport
+	issuers := make(map[string]*htmlIssuer)
+	aggregates := newAggregates()
+
+	for r := range results {
+		for _, a := range aggregates {
+			a.Add(r)
+		}
+
+		f := htmlFinding{Number: report.Certificates, Type: r.Type, Source: r.Source}
+		if r.Cert != nil {
+			f.Issuer = fmt.Sprintf("%s, %s", r.Cert.Issuer.CommonName, strings.Join(r.Cert.Issuer.Organization, ", "))
+			f.CAOperator = caOperator(r.Cert)
+			f.CN = r.Cert.Subject.CommonName
+			f.Serial = formatSerial(r.Cert.SerialNumber)
+		}
+		report.Certificates++
+
+		is, ok := issuers[f.Issuer]
+		if !ok {
+			is = &htmlIssuer{Issuer: f.Issuer}
+			issuers[f.Issuer] = is
+			report.Issuers = append(report.Issuers, is)
+		}
+		is.Certificates++
+
+		for _, e := range r.Errors.List() {
+			switch {
+			case e.Priority() >= errors.Error:
+				is.Errors++
+			case e.Priority() == errors.Warning:
+				is.Warnings++
+			case e.Priority() == errors.Notice:
+				is.Notices++
+			default:
+				is.Infos++
+			}
+
+			f.Severity = strings.ToUpper(e.Priority().String())
+			f.Message = e.Error()
+			report.Findings = append(report.Findings, f)
+		}
+	}
+
+	sort.Slice(report.Issuers, func(i, j int) bool {
+		return report.Issuers[i].Issuer < report.Issuers[j].Issuer
+	})
+
+	// Report the findings over all certificates in this bulk run
+	summary := checkAggregates(aggregates)
+	for _, e := range summary.List() {
+		report.Summary = append(report.Summary, htmlFinding{
+			Severity: strings.ToUpper(e.Priority().String()),
+			Message:  e.Error(),
+		})
+	}
+
+	return htmlTemplate.Execute(w, report)
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"lower": strings.ToLower,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>certlint report</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { background: #eee; cursor: pointer; }
+.emergency, .alert, .critical, .error { background: #f8d7da; }
+.warning { background: #fff3cd; }
+.notice { background: #d1ecf1; }
+.info, .debug { background: #f8f9fa; }
+</style>
+</head>
+<body>
+<h1>certlint report</h1>
+<p>Checked {{.Certificates}} certificates.</p>
+
+<h2>Issuers</h2>
+<table class="sortable">
+<thead><tr><th>Issuer</th><th>Certificates</th><th>Errors</th><th>Warnings</th><th>Notices</th><th>Info</th></tr></thead>
+<tbody>
+{{range .Issuers}}<tr><td>{{.Issuer}}</td><td>{{.Certificates}}</td><td>{{.Errors}}</td><td>{{.Warnings}}</td><td>{{.Notices}}</td><td>{{.Infos}}</td></tr>
+{{end}}</tbody>
+</table>
+{{if .Summary}}
+<h2>Summary</h2>
+<table>
+<thead><tr><th>Severity</th><th>Finding</th></tr></thead>
+<tbody>
+{{range .Summary}}<tr class="{{lower .Severity}}"><td>{{.Severity}}</td><td>{{.Message}}</td></tr>
+{{end}}</tbody>
+</table>
+{{end}}
+<h2>Findings</h2>
+<p>
+<input id="filter" type="search" placeholder="Filter findings">
+<select id="severity">
+<option value="">All severities</option>
+<option>CRITICAL</option><option>ERROR</option><option>WARNING</option><option>NOTICE</option><option>INFO</option>
+</select>
+</p>
+<table id="findings" class="sortable">
+<thead><tr><th>Number</th><th>Issuer</th><th>CA Operator</th><th>CN</th><th>Serial</th><th>Type</th><th>Severity</th><th>Finding</th><th>File</th></tr></thead>
+<tbody>
+{{range .Findings}}<tr class="{{lower .Severity}}"><td>{{.Number}}</td><td>{{.Issuer}}</td><td>{{.CAOperator}}</td><td>{{.CN}}</td><td>{{.Serial}}</td><td>{{.Type}}</td><td>{{.Severity}}</td><td>{{.Message}}</td><td>{{.Source}}</td></tr>
+{{end}}</tbody>
+</table>
+
+<script>
+// Sort a table by the clicked column
+document.querySelectorAll("table.sortable th").forEach(function(th) {
+	th.addEventListener("click", function() {
+		var tbody = th.closest("table").tBodies[0];
+		var col = th.cellIndex;
+		var asc = th.dataset.order !== "asc";
+		th.dataset.order = asc ? "asc" : "desc";
+		var rows = Array.prototype.slice.call(tbody.rows);
+		rows.sort(function(a, b) {
+			var x = a.cells[col].textContent, y = b.cells[col].textContent;
+			var n = x - y;
+			var c = isNaN(n) ? x.localeCompare(y) : n;
+			return asc ? c : -c;
+		});
+		rows.forEach(function(r) { tbody.appendChild(r); });
+	});
+});
+
+// Filter the findings on text and severity
+function filter() {
+	var text = document.getElementById("filter").value.toLowerCase();
+	var severity = document.getElementById("severity").value;
+	document.querySelectorAll("#findings tbody tr").forEach(function(r) {
+		var show = r.textContent.toLowerCase().indexOf(text) >= 0 &&
+			(severity === "" || r.cells[6].textContent === severity);
+		r.style.display = show ? "" : "none";
+	});
+}
+document.getElementById("filter").addEventListener("input", filter);
+document.getElementById("severity").addEventListener("change", filter);
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteHTML(t *testing.T) {
+	issuer := "./testdata/golden/issuer.pem"
+	ch := make(chan testResult, 2)
+	ch <- do(nil, getCertificate("./testdata/httpsocsp.pem"), &issuer, true, true)
+	ch <- do(nil, getCertificate("./testdata/whitespacecontrol.pem"), &issuer, true, true)
+	close(ch)
+
+	var buf bytes.Buffer
+	if err := writeHTML(&buf, ch); err != nil {
+		t.Fatal(err)
+	}
+	html := buf.String()
+
+	for _, want := range []string{
+		"Checked 2 certificates",
+		"<td>Certlint Test Root CA, Certlint Test</td><td>2</td>",
+		`<tr class="error">`,
+		"Certificate contains an OCSP server using https",
+		// Values from the certificate must be escaped
+		"whitespacecontrol.example.com ",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected '%s' in the HTML report", want)
+		}
+	}
+	if strings.Contains(html, "<script src") || strings.Contains(html, "<link") {
+		t.Error("HTML report should not load external resources")
+	}
+}
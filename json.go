@@ -0,0 +1,78 @@
+package main
+
+import (
+	"crypto/x509"
+	"strings"
+
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// jsonResult is the JSON representation of the result of a single certificate
+type jsonResult struct {
+	Type      string        `json:"type"`
+	Precert   bool          `json:"precertificate,omitempty"`
+	Trusted   bool          `json:"trusted"`
+	TrustedBy []string      `json:"trusted_by,omitempty"`
+	Chains    [][]string    `json:"chains,omitempty"`
+	Findings  []jsonFinding `json:"findings"`
+}
+
+// jsonFinding is the JSON representation of a single finding
+type jsonFinding struct {
+	Severity string `json:"severity"`
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message"`
+	Check    string `json:"check,omitempty"`
+	Citation string `json:"citation,omitempty"`
+	Count    int    `json:"count,omitempty"`
+}
+
+// newJSONResult converts a testResult in its JSON representation
+func newJSONResult(r testResult) jsonResult {
+	return jsonResult{
+		Type:      r.Type,
+		Precert:   r.Precert,
+		Trusted:   r.Trusted,
+		TrustedBy: r.TrustedBy,
+		Chains:    jsonChains(r.Chains),
+		Findings:  jsonFindings(r.Errors),
+	}
+}
+
+// jsonChains converts the chains in lists of subjects, from the issuer to the
+// root
+func jsonChains(chains [][]*x509.Certificate) [][]string {
+	var l [][]string
+	for _, chain := range chains {
+		subjects := make([]string, len(chain))
+		for i, c := range chain {
+			subjects[i] = c.Subject.String()
+		}
+		l = append(l, subjects)
+	}
+	return l
+}
+
+// jsonFindings converts all findings in their JSON representation
+func jsonFindings(e *errors.Errors) []jsonFinding {
+	findings := []jsonFinding{}
+	if e == nil {
+		return findings
+	}
+	for _, err := range e.List() {
+		m, _ := checks.Lookup(err.Check())
+		f := jsonFinding{
+			Severity: strings.ToUpper(err.Priority().String()),
+			Code:     err.Code(),
+			Message:  err.Error(),
+			Check:    m.ID,
+			Citation: m.Citation,
+		}
+		if err.Count() > 1 {
+			f.Count = err.Count()
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+func TestJSONResult(t *testing.T) {
+	e := errors.New(nil)
+	ku := errors.New(nil)
+	ku.Err("Certificate has no key usage set")
+	ku.SetCheck("Key Usage Check")
+	e.Append(ku)
+	e.Info("commonName field is deprecated")
+
+	b, err := json.Marshal(newJSONResult(testResult{Type: "DV", Trusted: true, Errors: e}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"DV","trusted":true,"findings":[{"severity":"ERROR","code":"CERTLINT_KU_001","message":"Certificate has no key usage set","check":"certificate/keyusage","citation":"RFC 5280 §4.2.1.3"},{"severity":"INFO","code":"CERTLINT_SUBJ_007","message":"commonName field is deprecated"}]}`
+	if string(b) != want {
+		t.Errorf("Unexpected JSON result\ngot:  %s\nwant: %s", b, want)
+	}
+
+	b, err = json.Marshal(newJSONResult(testResult{Type: "EV"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `{"type":"EV","trusted":false,"findings":[]}`
+	if string(b) != want {
+		t.Errorf("Unexpected JSON result\ngot:  %s\nwant: %s", b, want)
+	}
+}
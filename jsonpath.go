@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// getJSONCertificate reads a JSON document from disk and returns the DER
+// encoded certificate found in the field at path. The field may either contain
+// a PEM encoded certificate or a base64 encoded DER certificate.
+func getJSONCertificate(file, path string) ([]byte, error) {
+	b, err := readFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return jsonCertificate(b, path)
+}
+
+// jsonCertificate returns the DER encoded certificate found in the field at
+// path of the JSON document b.
+func jsonCertificate(b []byte, path string) ([]byte, error) {
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	v, err := jsonField(doc, path)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("json field %s is not a string", path)
+	}
+
+	// decode pem
+	if block, _ := pem.Decode([]byte(s)); block != nil {
+		return block.Bytes, nil
+	}
+
+	s = strings.TrimSpace(s)
+	der, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		der, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	}
+	// A DER encoded certificate always starts with a SEQUENCE
+	if err != nil || len(der) == 0 || der[0] != 0x30 {
+		return nil, fmt.Errorf("json field %s contains no PEM or base64 encoded certificate", path)
+	}
+	return der, nil
+}
+
+// jsonField walks the decoded JSON document v and returns the value at path.
+// The path is a simple JSONPath like expression, e.g. $.items[0].certificate,
+// where array elements are selected by index.
+func jsonField(v interface{}, path string) (interface{}, error) {
+	path = strings.TrimPrefix(path, "$")
+	path = strings.Replace(path, "[", ".", -1)
+	path = strings.Replace(path, "]", "", -1)
+
+	for _, f := range strings.Split(path, ".") {
+		if len(f) == 0 {
+			continue
+		}
+		switch t := v.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if v, ok = t[f]; !ok {
+				return nil, fmt.Errorf("json field %s not found", f)
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(f)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, fmt.Errorf("json array index %s out of range", f)
+			}
+			v = t[i]
+		default:
+			return nil, fmt.Errorf("json field %s not found", f)
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+)
+
+func TestJSONCertificate(t *testing.T) {
+	block, _ := pem.Decode([]byte(certBench))
+	if block == nil {
+		t.Fatal("Failed to decode certificate")
+	}
+
+	// Sample payload as returned by a certificate management API
+	payload, err := json.Marshal(map[string]interface{}{
+		"status": "issued",
+		"data": map[string]interface{}{
+			"certificate": certBench,
+			"chain": []interface{}{
+				map[string]interface{}{"der": base64.StdEncoding.EncodeToString(block.Bytes)},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"$.data.certificate", "data.certificate", "$.data.chain[0].der", "data.chain.0.der"} {
+		der, err := jsonCertificate(payload, path)
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %s", path, err.Error())
+			continue
+		}
+		if !bytes.Equal(der, block.Bytes) {
+			t.Errorf("Unexpected certificate for %s", path)
+		}
+	}
+
+	for _, path := range []string{"$.data.missing", "$.data.chain[1].der", "$.data.chain", "$.status"} {
+		if _, err := jsonCertificate(payload, path); err == nil {
+			t.Errorf("Expected an error for %s", path)
+		}
+	}
+}
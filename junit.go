@@ -0,0 +1,187 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// junitGenericCase is the test case used for findings that are not reported by
+// a registered check, like encoding and chain errors
+const junitGenericCase = "Certificate Check"
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// newJUnitTestSuite converts the result of a certificate in a test suite with
+// a test case per check, a check fails on findings with priority Error or
+// higher. Findings with a lower priority are added as output.
+func newJUnitTestSuite(r testResult) junitTestSuite {
+	var suite junitTestSuite
+	if r.Cert != nil {
+		suite.Name = fmt.Sprintf("%s (%s)", r.Cert.Subject.CommonName, formatSerial(r.Cert.SerialNumber))
+		suite.Properties = []junitProperty{
+			{"fingerprint", fmt.Sprintf("%x", sha256.Sum256(r.Cert.Raw))},
+			{"type", r.Type},
+		}
+		if len(r.Source) > 0 {
+			suite.Properties = append(suite.Properties, junitProperty{"source", r.Source})
+		}
+	} else {
+		suite.Name = "Invalid certificate"
+	}
+
+	findings := make(map[string][]errors.Err)
+	for _, e := range r.Errors.List() {
+		name := e.Check()
+		if len(name) == 0 {
+			name = junitGenericCase
+		}
+		findings[name] = append(findings[name], e)
+	}
+
+	names := append(checks.Certificate.Names(), checks.Extensions.Names()...)
+	names = append(names, junitGenericCase)
+	sort.Strings(names)
+	for _, name := range names {
+		tc := newJUnitTestCase(name, findings[name])
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+		suite.Cases = append(suite.Cases, tc)
+		suite.Tests++
+	}
+
+	return suite
+}
+
+// junitClassName returns the class name of the test case of a check, the check
+// name in lowercase with dashes
+func junitClassName(check string) string {
+	return "certlint." + strings.Trim(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, check), "-")
+}
+
+// newJUnitTestCase returns the test case of a check, which fails on findings
+// with priority Error or higher
+func newJUnitTestCase(name string, findings []errors.Err) junitTestCase {
+	tc := junitTestCase{
+		Name:      name,
+		ClassName: junitClassName(name),
+	}
+
+	var message string
+	var failures, output []string
+	for _, e := range findings {
+		line := fmt.Sprintf("%s: %s", strings.ToUpper(e.Priority().String()), e.Error())
+		if e.Priority() >= errors.Error {
+			if len(failures) == 0 {
+				message = e.Error()
+			}
+			failures = append(failures, line)
+		} else {
+			output = append(output, line)
+		}
+	}
+	if len(failures) > 0 {
+		tc.Failure = &junitFailure{
+			Message: message,
+			Type:    "ERROR",
+			Text:    strings.Join(failures, "\n"),
+		}
+	}
+	tc.SystemOut = strings.Join(output, "\n")
+	return tc
+}
+
+// saveJUnit writes all results as a JUnit XML report
+func saveJUnit(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return writeJUnit(file, results)
+}
+
+func writeJUnit(w io.Writer, results <-chan testResult) error {
+	suites := junitTestSuites{Name: "certlint"}
+	aggregates := newAggregates()
+	for r := range results {
+		for _, a := range aggregates {
+			a.Add(r)
+		}
+		suite := newJUnitTestSuite(r)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	// Findings over all certificates in this run are a separate test suite
+	if list := checkAggregates(aggregates).List(); len(list) > 0 {
+		suite := junitTestSuite{Name: "Summary", Tests: 1}
+		tc := newJUnitTestCase(junitGenericCase, list)
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+		suite.Cases = append(suite.Cases, tc)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
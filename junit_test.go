@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	issuer := "./testdata/golden/issuer.pem"
+	ch := make(chan testResult, 2)
+	ch <- do(nil, getCertificate("./testdata/httpsocsp.pem"), &issuer, true, true)
+	ch <- do(nil, []byte("not a certificate"), nil, true, true)
+	close(ch)
+
+	var buf bytes.Buffer
+	if err := writeJUnit(&buf, ch); err != nil {
+		t.Fatal(err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatal(err)
+	}
+	if len(suites.Suites) != 2 {
+		t.Fatalf("Expected 2 test suites, got %d", len(suites.Suites))
+	}
+
+	cases := make(map[string]junitTestCase)
+	for _, tc := range suites.Suites[0].Cases {
+		cases[tc.Name] = tc
+	}
+	if tc := cases["Certificate Revocation Information Check"]; tc.Failure == nil {
+		t.Error("Expected the revocation check to fail")
+	}
+	if tc := cases["Certificate Transparency Check"]; tc.Failure != nil || len(tc.SystemOut) == 0 {
+		t.Errorf("Expected the CT check to pass with output, got %+v", tc)
+	}
+	if tc := cases["Key Usage Check"]; tc.Failure != nil {
+		t.Errorf("Expected the key usage check to pass, got %+v", tc.Failure)
+	}
+
+	if suites.Suites[1].Failures != 1 {
+		t.Errorf("Expected 1 failure for an invalid certificate, got %d", suites.Suites[1].Failures)
+	}
+	if suites.Failures != suites.Suites[0].Failures+suites.Suites[1].Failures {
+		t.Errorf("Unexpected total failures %d", suites.Failures)
+	}
+}
+
+func TestWriteJUnitSummary(t *testing.T) {
+	ch := make(chan testResult, 2)
+	for i := 0; i < 2; i++ {
+		r := newTestResult(t, 42, nil)
+		r.Errors = errors.New(nil)
+		ch <- r
+	}
+	close(ch)
+
+	var buf bytes.Buffer
+	if err := writeJUnit(&buf, ch); err != nil {
+		t.Fatal(err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatal(err)
+	}
+	if len(suites.Suites) != 3 {
+		t.Fatalf("Expected 3 test suites, got %d", len(suites.Suites))
+	}
+
+	// The serial number collision fails the summary
+	summary := suites.Suites[2]
+	if summary.Name != "Summary" || summary.Failures != 1 || len(summary.Cases) != 1 {
+		t.Fatalf("Unexpected summary test suite %+v", summary)
+	}
+	if f := summary.Cases[0].Failure; f == nil || !strings.Contains(f.Message, "share serial number") {
+		t.Errorf("Expected a failure for the serial number collision, got %+v", f)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// markdownEscaper escapes the characters that break a Markdown table cell
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\r", " ", "\n", " ")
+
+// saveMarkdown writes all results as a Markdown report
+func saveMarkdown(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return writeMarkdown(file, results)
+}
+
+// writeMarkdown writes a findings table per certificate with findings,
+// followed by a summary of all certificates.
+func writeMarkdown(w io.Writer, results <-chan testResult) error {
+	bw := bufio.NewWriter(w)
+	aggregates := newAggregates()
+	counts := make(map[errors.Priority]int)
+	var certificates, failed int
+
+	fmt.Fprintln(bw, "# certlint report")
+	for r := range results {
+		for _, a := range aggregates {
+			a.Add(r)
+		}
+		certificates++
+
+		list := r.Errors.List()
+		if len(list) == 0 {
+			continue
+		}
+		failed++
+
+		name := "Invalid certificate"
+		if r.Cert != nil {
+			name = fmt.Sprintf("%s (%s)", r.Cert.Subject.CommonName, formatSerial(r.Cert.SerialNumber))
+		}
+		fmt.Fprintf(bw, "\n## %s\n\n", markdownEscaper.Replace(name))
+		if len(r.Source) > 0 {
+			fmt.Fprintf(bw, "File: %s\n\n", r.Source)
+		}
+		fmt.Fprintf(bw, "Type: %s\n\n", r.Type)
+		fmt.Fprintln(bw, "| Severity | Check | Finding |")
+		fmt.Fprintln(bw, "|----------|-------|---------|")
+		for _, e := range list {
+			counts[e.Priority()]++
+			check := e.Check()
+			if len(check) == 0 {
+				check = "-"
+			}
+			fmt.Fprintf(bw, "| %s | %s | %s |\n", strings.ToUpper(e.Priority().String()),
+				markdownEscaper.Replace(check), markdownEscaper.Replace(e.Error()))
+		}
+	}
+
+	fmt.Fprintln(bw, "\n## Summary")
+	fmt.Fprintf(bw, "\nChecked %d certificates, %d with findings.\n\n", certificates, failed)
+	fmt.Fprintln(bw, "| Severity | Findings |")
+	fmt.Fprintln(bw, "|----------|----------|")
+	for p := errors.Emergency; p >= errors.Debug; p-- {
+		if counts[p] > 0 {
+			fmt.Fprintf(bw, "| %s | %d |\n", strings.ToUpper(p.String()), counts[p])
+		}
+	}
+
+	// Findings over all certificates in this run
+	summary := checkAggregates(aggregates)
+	if list := summary.List(); len(list) > 0 {
+		fmt.Fprintln(bw)
+		for _, e := range list {
+			fmt.Fprintf(bw, "- %s: %s\n", strings.ToUpper(e.Priority().String()), e.Error())
+		}
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	issuer := "./testdata/golden/issuer.pem"
+	ch := make(chan testResult, 1)
+	ch <- do(nil, getCertificate("./testdata/httpsocsp.pem"), &issuer, true, true)
+	close(ch)
+
+	var buf bytes.Buffer
+	if err := writeMarkdown(&buf, ch); err != nil {
+		t.Fatal(err)
+	}
+	md := buf.String()
+
+	for _, want := range []string{
+		"# certlint report",
+		"| Severity | Check | Finding |",
+		"| ERROR | Certificate Revocation Information Check | Certificate contains an OCSP server using https",
+		"Checked 1 certificates, 1 with findings.",
+		"| ERROR | 1 |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Expected '%s' in the Markdown report, got:\n%s", want, md)
+		}
+	}
+}
+
+func TestMarkdownEscaper(t *testing.T) {
+	if got := markdownEscaper.Replace("a|b\nc"); got != "a\\|b c" {
+		t.Errorf("Expected escaped table cell, got '%s'", got)
+	}
+}
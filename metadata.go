@@ -0,0 +1,52 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+)
+
+// caOperators maps hex encoded subject key identifiers of issuing CAs to the
+// name of the CA operator, loaded with -ca-metadata.
+var caOperators map[string]string
+
+// loadCAMetadata reads a JSON object mapping hex encoded subject key
+// identifiers of CA certificates to the name of their operator, e.g.
+//
+// {"da:40:77:43:65:1c:f8:fe:a7:e3:f4:64:82:3e:4d:43:13:22:31:02": "GlobalSign"}
+func loadCAMetadata(file string) (map[string]string, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]string
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	operators := make(map[string]string, len(m))
+	for ski, name := range m {
+		operators[normalizeKeyID(ski)] = name
+	}
+	return operators, nil
+}
+
+// caOperator returns the name of the operator of the CA that issued the
+// certificate, based on the authority key identifier.
+func caOperator(c *x509.Certificate) string {
+	if c == nil || len(c.AuthorityKeyId) == 0 {
+		return ""
+	}
+	return caOperators[hex.EncodeToString(c.AuthorityKeyId)]
+}
+
+// normalizeKeyID returns the key identifier as lowercase hex without
+// separators.
+func normalizeKeyID(id string) string {
+	id = strings.ToLower(strings.TrimSpace(id))
+	id = strings.Replace(id, ":", "", -1)
+	return strings.Replace(id, " ", "", -1)
+}
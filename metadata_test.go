@@ -0,0 +1,44 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestCAOperator(t *testing.T) {
+	f, err := ioutil.TempFile("", "certlint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+
+	f.WriteString(`{
+	"DA:40:77:43:65:1C:F8:FE:A7:E3:F4:64:82:3E:4D:43:13:22:31:02": "GlobalSign",
+	"0102030405060708090a0b0c0d0e0f1011121314": "Other CA"
+}`)
+	f.Close()
+
+	m, err := loadCAMetadata(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	caOperators = m
+	defer func() { caOperators = nil }()
+
+	block, _ := pem.Decode([]byte(certBench))
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op := caOperator(c); op != "GlobalSign" {
+		t.Errorf("Expected CA operator GlobalSign, got '%s'", op)
+	}
+
+	c.AuthorityKeyId = []byte{0xff}
+	if op := caOperator(c); op != "" {
+		t.Errorf("Expected no CA operator, got '%s'", op)
+	}
+}
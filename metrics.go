@@ -0,0 +1,72 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	metricCertificates = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "certlint_certificates_total",
+		Help: "Number of checked certificates.",
+	})
+	metricFindings = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "certlint_findings_total",
+		Help: "Number of findings by severity and check.",
+	}, []string{"severity", "check"})
+	metricAIAFetch = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "certlint_aia_fetch_duration_seconds",
+		Help:    "Duration of downloading issuer certificates from the Authority Information Access.",
+		Buckets: prometheus.DefBuckets,
+	})
+	metricAIAErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "certlint_aia_fetch_errors_total",
+		Help: "Number of failed issuer certificate downloads.",
+	})
+	metricQueueDepth = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "certlint_queue_depth",
+		Help: "Number of certificates waiting to be checked in bulk mode.",
+	}, func() float64 {
+		return float64(len(jobs))
+	})
+)
+
+func init() {
+	prometheus.MustRegister(metricCertificates, metricFindings, metricAIAFetch, metricAIAErrors, metricQueueDepth)
+}
+
+// countResult updates the metrics with a checked certificate and its findings
+func countResult(result testResult) {
+	metricCertificates.Inc()
+	for _, err := range result.Errors.List() {
+		metricFindings.WithLabelValues(strings.ToUpper(err.Priority().String()), err.Check()).Inc()
+	}
+}
+
+// countAIAFetch updates the metrics with the duration and result of an issuer
+// certificate download
+func countAIAFetch(d time.Duration, err error) {
+	metricAIAFetch.Observe(d.Seconds())
+	if err != nil {
+		metricAIAErrors.Inc()
+	}
+}
+
+// serveMetrics serves the Prometheus metrics on /metrics of addr in the
+// background, an error is returned when we can't listen on addr.
+func serveMetrics(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go http.Serve(l, mux)
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCountResult(t *testing.T) {
+	certificates := testutil.ToFloat64(metricCertificates)
+
+	result := do(lru.New(200), getCertificate("./testdata/nokeyusage.pem"), nil, true, true)
+	if n := testutil.ToFloat64(metricCertificates) - certificates; n != 1 {
+		t.Errorf("Expected 1 more checked certificate, got %g", n)
+	}
+
+	var total float64
+	for _, err := range result.Errors.List() {
+		total += testutil.ToFloat64(metricFindings.WithLabelValues(strings.ToUpper(err.Priority().String()), err.Check()))
+	}
+	if total < float64(len(result.Errors.List())) {
+		t.Errorf("Expected at least %d findings counted, got %g", len(result.Errors.List()), total)
+	}
+
+	failed := testutil.ToFloat64(metricAIAErrors)
+	countAIAFetch(time.Second, fmt.Errorf("timeout"))
+	if testutil.ToFloat64(metricAIAErrors)-failed != 1 {
+		t.Error("Expected a failed AIA fetch to be counted")
+	}
+}
+
+func TestServeMetrics(t *testing.T) {
+	// Find a free port to listen on
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	if err = serveMetrics(addr); err != nil {
+		t.Fatal(err)
+	}
+	if err = serveMetrics(addr); err == nil {
+		t.Error("Expected an error when the address is in use")
+	}
+
+	resp, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	for _, name := range []string{"certlint_certificates_total", "certlint_queue_depth"} {
+		if !strings.Contains(string(body), name) {
+			t.Errorf("Expected metric %s in %s", name, body)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+)
+
+// jsonBulkResult is the JSON representation of a certificate checked in bulk
+// mode, summary findings over all certificates have Summary set.
+type jsonBulkResult struct {
+	Source      string        `json:"source,omitempty"`
+	Fingerprint string        `json:"fingerprint,omitempty"`
+	Subject     string        `json:"subject,omitempty"`
+	Issuer      string        `json:"issuer,omitempty"`
+	Serial      string        `json:"serial,omitempty"`
+	Type        string        `json:"type,omitempty"`
+	Pem         string        `json:"pem,omitempty"`
+	Summary     bool          `json:"summary,omitempty"`
+	Findings    []jsonFinding `json:"findings"`
+}
+
+// newJSONBulkResult converts a testResult in its bulk JSON representation
+func newJSONBulkResult(r testResult) jsonBulkResult {
+	j := jsonBulkResult{
+		Source:   r.Source,
+		Type:     r.Type,
+		Pem:      r.Pem,
+		Findings: jsonFindings(r.Errors),
+	}
+	if r.Cert != nil {
+		j.Fingerprint = fmt.Sprintf("%x", sha256.Sum256(r.Cert.Raw))
+		j.Subject = r.Cert.Subject.String()
+		j.Issuer = r.Cert.Issuer.String()
+		j.Serial = formatSerial(r.Cert.SerialNumber)
+	}
+	return j
+}
+
+// saveNDJSON writes one JSON object per line for each result as soon as it is
+// received, followed by the summary findings over all certificates.
+func saveNDJSON(filename string, include bool) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return writeNDJSON(file, results, include)
+}
+
+func writeNDJSON(w io.Writer, results <-chan testResult, include bool) error {
+	enc := json.NewEncoder(w)
+	aggregates := newAggregates()
+
+	for r := range results {
+		for _, a := range aggregates {
+			a.Add(r)
+		}
+		j := newJSONBulkResult(r)
+
+		// Do we need to include the certificate
+		if include && r.Cert != nil {
+			j.Pem = string(pem.EncodeToMemory(&pem.Block{
+				Type:  "CERTIFICATE",
+				Bytes: r.Der,
+			}))
+		}
+		if err := enc.Encode(j); err != nil {
+			return err
+		}
+	}
+
+	// Report the findings over all certificates in this bulk run
+	summary := checkAggregates(aggregates)
+	if len(summary.List()) > 0 {
+		return enc.Encode(jsonBulkResult{Summary: true, Findings: jsonFindings(summary)})
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteNDJSON(t *testing.T) {
+	ch := make(chan testResult, 2)
+	ch <- do(nil, getCertificate("./testdata/httpsocsp.pem"), nil, true, true)
+	ch <- do(nil, []byte("not a certificate"), nil, true, true)
+	close(ch)
+
+	var buf bytes.Buffer
+	if err := writeNDJSON(&buf, ch, true); err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []jsonBulkResult
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var r jsonBulkResult
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("Invalid JSON line '%s': %s", scanner.Text(), err.Error())
+		}
+		lines = append(lines, r)
+	}
+
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+	if len(lines[0].Fingerprint) != 64 || lines[0].Subject != "CN=httpsocsp.example.com" || lines[0].Type != "DV" {
+		t.Errorf("Unexpected certificate result %+v", lines[0])
+	}
+	if len(lines[0].Pem) == 0 {
+		t.Error("Expected the certificate to be included")
+	}
+	if len(lines[0].Findings) == 0 {
+		t.Error("Expected findings for the certificate")
+	}
+	if len(lines[1].Fingerprint) != 0 || len(lines[1].Findings) == 0 {
+		t.Errorf("Unexpected result for an invalid certificate %+v", lines[1])
+	}
+}
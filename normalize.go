@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+
+	certasn1 "github.com/weyhmueller/certlint/asn1"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// normalize re-encodes the certificate structure as strict DER, encodings that
+// are accepted by the parser but are not DER (like an explicitly encoded
+// default value) are changed in the returned certificate. Only the top level
+// fields of the Certificate and TBSCertificate are re-encoded, the fields kept
+// as asn1.RawValue (like the names) and the extension values are copied as is.
+func normalize(der []byte) ([]byte, error) {
+	var c certasn1.Certificate
+	rest, err := asn1.Unmarshal(der, &c)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("Trailing data after certificate")
+	}
+	return asn1.Marshal(c)
+}
+
+// writeNormalized writes the normalized certificate as PEM to a file and reports
+// if the encoding has been changed.
+func writeNormalized(filename string, der []byte) *errors.Errors {
+	var e = errors.New(nil)
+
+	n, err := normalize(der)
+	if err != nil {
+		e.Err("Failed to normalize certificate: %s", err.Error())
+		return e
+	}
+	if !bytes.Equal(n, der) {
+		e.Warning("Certificate is not DER encoded, the encoding changed during normalization")
+		e.Warning("Normalized certificate signature is no longer valid, the certificate has to be signed again")
+	}
+
+	err = ioutil.WriteFile(filename, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: n}), 0644)
+	if err != nil {
+		e.Err("Failed to write normalized certificate: %s", err.Error())
+	}
+
+	return e
+}
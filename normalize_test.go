@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	der := getCertificate("./testdata/precertfinal.pem")
+	n, err := normalize(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(n, der) {
+		t.Error("Unexpected change while normalizing a DER encoded certificate")
+	}
+
+	der = getCertificate("./testdata/nonder.pem")
+	n, err = normalize(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(n, der) {
+		t.Error("Expected a change while normalizing a non DER encoded certificate")
+	}
+
+	// The normalized certificate should not change anymore
+	n2, err := normalize(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(n, n2) {
+		t.Error("Unexpected change while normalizing a normalized certificate")
+	}
+}
+
+func TestWriteNormalized(t *testing.T) {
+	f, err := ioutil.TempFile("", "certlint-normalize")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	e := writeNormalized(f.Name(), getCertificate("./testdata/nonder.pem"))
+	var codes []string
+	for _, err := range e.List() {
+		codes = append(codes, err.Code())
+	}
+	if strings.Join(codes, " ") != "CERTLINT_NORM_002 CERTLINT_NORM_004" {
+		t.Errorf("Unexpected findings while normalizing a non DER encoded certificate: %v", codes)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetBatch is the number of rows buffered before they are written
+const parquetBatch = 1024
+
+// parquetRow is a single finding of a certificate, certificates are repeated
+// for every finding so the file can be queried without joins. The findings over
+// all certificates have empty certificate columns.
+type parquetRow struct {
+	Source      string    `parquet:"source"`
+	Fingerprint string    `parquet:"fingerprint"`
+	Subject     string    `parquet:"subject"`
+	Issuer      string    `parquet:"issuer"`
+	CAOperator  string    `parquet:"ca_operator"`
+	Serial      string    `parquet:"serial"`
+	Type        string    `parquet:"type,enum"`
+	Trusted     bool      `parquet:"trusted"`
+	NotBefore   time.Time `parquet:"not_before,timestamp(millisecond)"`
+	NotAfter    time.Time `parquet:"not_after,timestamp(millisecond)"`
+	Check       string    `parquet:"check"`
+	Severity    string    `parquet:"severity,enum"`
+	Priority    int32     `parquet:"priority"`
+	Message     string    `parquet:"message"`
+}
+
+// saveParquet writes all results as a Parquet file
+func saveParquet(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return writeParquet(file, results)
+}
+
+func writeParquet(w io.Writer, results <-chan testResult) error {
+	pw := parquet.NewGenericWriter[parquetRow](w)
+
+	rows := make([]parquetRow, 0, parquetBatch)
+	aggregates := newAggregates()
+	for r := range results {
+		for _, a := range aggregates {
+			a.Add(r)
+		}
+
+		var c parquetRow
+		c.Source = r.Source
+		c.Type = r.Type
+		c.Trusted = r.Trusted
+		if r.Cert != nil {
+			c.Fingerprint = fmt.Sprintf("%x", sha256.Sum256(r.Cert.Raw))
+			c.Subject = r.Cert.Subject.String()
+			c.Issuer = r.Cert.Issuer.String()
+			c.CAOperator = caOperator(r.Cert)
+			c.Serial = formatSerial(r.Cert.SerialNumber)
+			c.NotBefore = r.Cert.NotBefore.UTC()
+			c.NotAfter = r.Cert.NotAfter.UTC()
+		}
+
+		for _, e := range r.Errors.List() {
+			row := c
+			row.Check = e.Check()
+			row.Severity = strings.ToUpper(e.Priority().String())
+			row.Priority = int32(e.Priority())
+			row.Message = e.Error()
+			rows = append(rows, row)
+		}
+
+		if len(rows) >= parquetBatch {
+			if _, err := pw.Write(rows); err != nil {
+				return err
+			}
+			rows = rows[:0]
+		}
+	}
+
+	// Findings over all certificates in this run have no certificate columns
+	for _, e := range checkAggregates(aggregates).List() {
+		rows = append(rows, parquetRow{
+			Check:    e.Check(),
+			Severity: strings.ToUpper(e.Priority().String()),
+			Priority: int32(e.Priority()),
+			Message:  e.Error(),
+		})
+	}
+
+	if len(rows) > 0 {
+		if _, err := pw.Write(rows); err != nil {
+			return err
+		}
+	}
+	return pw.Close()
+}
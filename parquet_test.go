@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+func TestWriteParquet(t *testing.T) {
+	issuer := "./testdata/golden/issuer.pem"
+	result := do(nil, getCertificate("./testdata/httpsocsp.pem"), &issuer, true, true)
+	ch := make(chan testResult, 1)
+	ch <- result
+	close(ch)
+
+	var buf bytes.Buffer
+	if err := writeParquet(&buf, ch); err != nil {
+		t.Skip(err)
+	}
+
+	rows, err := parquet.Read[parquetRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != len(result.Errors.List()) {
+		t.Fatalf("Expected a row per finding, got %d rows for %d findings", len(rows), len(result.Errors.List()))
+	}
+	for _, r := range rows {
+		if r.Serial != formatSerial(result.Cert.SerialNumber) {
+			t.Errorf("Expected serial %s, got %s", formatSerial(result.Cert.SerialNumber), r.Serial)
+		}
+		if !r.NotAfter.Equal(result.Cert.NotAfter) {
+			t.Errorf("Expected NotAfter %s, got %s", result.Cert.NotAfter, r.NotAfter)
+		}
+	}
+}
+
+func TestWriteParquetSummary(t *testing.T) {
+	ch := make(chan testResult, 2)
+	for i := 0; i < 2; i++ {
+		r := newTestResult(t, 42, nil)
+		r.Errors = errors.New(nil)
+		ch <- r
+	}
+	close(ch)
+
+	var buf bytes.Buffer
+	if err := writeParquet(&buf, ch); err != nil {
+		t.Skip(err)
+	}
+
+	rows, err := parquet.Read[parquetRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || len(rows[0].Fingerprint) != 0 || !strings.Contains(rows[0].Message, "share serial number") {
+		t.Errorf("Expected a summary row for the serial number collision, got %+v", rows)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// readPKCS12 returns the end-entity certificate of a PKCS#12 archive followed
+// by the CA certificates of the embedded chain. Archives without a private key
+// are read as trust store.
+func readPKCS12(file, password string) ([][]byte, error) {
+	b, err := readFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	_, cert, caCerts, err := pkcs12.DecodeChain(b, password)
+	if err == nil {
+		ders := [][]byte{cert.Raw}
+		for _, c := range caCerts {
+			ders = append(ders, c.Raw)
+		}
+		return ders, nil
+	}
+
+	certs, tsErr := pkcs12.DecodeTrustStore(b, password)
+	if tsErr != nil {
+		return nil, fmt.Errorf("%s: %s", file, err.Error())
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("%s: PKCS#12 archive contains no certificates", file)
+	}
+
+	var all [][]byte
+	for _, c := range certs {
+		all = append(all, c.Raw)
+	}
+	ee := endEntity(all)
+	ders := [][]byte{ee}
+	for _, der := range all {
+		if !bytes.Equal(der, ee) {
+			ders = append(ders, der)
+		}
+	}
+	return ders, nil
+}
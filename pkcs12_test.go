@@ -0,0 +1,28 @@
+package main
+
+import (
+	"crypto/x509"
+	"testing"
+)
+
+func TestReadPKCS12(t *testing.T) {
+	ders, err := readPKCS12("./testdata/bundle/chain.p12", "certlint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ders) != 2 {
+		t.Fatalf("Expected the certificate and its root, got %d certificates", len(ders))
+	}
+
+	c, err := x509.ParseCertificate(ders[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Subject.CommonName != "p12.example.com" {
+		t.Errorf("Expected end-entity certificate p12.example.com first, got %s", c.Subject.CommonName)
+	}
+
+	if _, err = readPKCS12("./testdata/bundle/complete.p7b", "certlint"); err == nil {
+		t.Error("Expected an error reading a PKCS#7 bundle as PKCS#12")
+	}
+}
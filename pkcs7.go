@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+)
+
+// oidSignedData is the content type of a PKCS#7 signedData structure, used
+// for certificate bundles (.p7b)
+var oidSignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+
+// RFC 2315 §7
+type pkcs7ContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+// RFC 2315 §9.1
+type pkcs7SignedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	ContentInfo      asn1.RawValue
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      asn1.RawValue
+}
+
+// isPKCS7 reports if der is a PKCS#7 signedData structure
+func isPKCS7(der []byte) bool {
+	var ci pkcs7ContentInfo
+	if _, err := asn1.Unmarshal(der, &ci); err != nil {
+		return false
+	}
+	return ci.ContentType.Equal(oidSignedData)
+}
+
+// parsePKCS7 returns the DER encoded certificates in a PKCS#7 signedData
+// certificate bundle
+func parsePKCS7(der []byte) ([][]byte, error) {
+	var ci pkcs7ContentInfo
+	if _, err := asn1.Unmarshal(der, &ci); err != nil {
+		return nil, fmt.Errorf("failed to parse PKCS#7 bundle: %s", err.Error())
+	}
+	if !ci.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("PKCS#7 content type %s is not signedData", ci.ContentType)
+	}
+
+	var sd pkcs7SignedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return nil, fmt.Errorf("failed to parse PKCS#7 signedData: %s", err.Error())
+	}
+
+	var certs [][]byte
+	rest := sd.Certificates.Bytes
+	for len(rest) > 0 {
+		var raw asn1.RawValue
+		var err error
+		if rest, err = asn1.Unmarshal(rest, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS#7 certificates: %s", err.Error())
+		}
+
+		// Skip the obsolete extended and attribute certificates
+		if raw.Class == asn1.ClassUniversal && raw.Tag == asn1.TagSequence {
+			certs = append(certs, raw.FullBytes)
+		}
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("PKCS#7 bundle contains no certificates")
+	}
+	return certs, nil
+}
+
+// pkcs7EndEntity returns the end-entity certificate of a PKCS#7 bundle
+func pkcs7EndEntity(der []byte) ([]byte, error) {
+	certs, err := parsePKCS7(der)
+	if err != nil {
+		return nil, err
+	}
+	return endEntity(certs), nil
+}
+
+// endEntity returns the certificate in a bundle that did not issue any of the
+// other certificates, the first certificate is returned if none is found.
+func endEntity(certs [][]byte) []byte {
+	var parsed []*x509.Certificate
+	for _, der := range certs {
+		if c, err := x509.ParseCertificate(der); err == nil {
+			parsed = append(parsed, c)
+		}
+	}
+
+	for _, c := range parsed {
+		var issuer bool
+		for _, o := range parsed {
+			if c != o && bytes.Equal(c.RawSubject, o.RawIssuer) && !bytes.Equal(o.RawSubject, o.RawIssuer) {
+				issuer = true
+				break
+			}
+		}
+		if !issuer {
+			return c.Raw
+		}
+	}
+	return certs[0]
+}
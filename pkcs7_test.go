@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"testing"
+)
+
+func TestPKCS7(t *testing.T) {
+	p7b, err := ioutil.ReadFile("./testdata/bundle/complete.p7b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certs, err := parsePKCS7(p7b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 3 {
+		t.Fatalf("Expected 3 certificates in bundle, got %d", len(certs))
+	}
+
+	// The end-entity certificate is linted for DER and PEM encoded bundles
+	p7c := pem.EncodeToMemory(&pem.Block{Type: "PKCS7", Bytes: p7b})
+	for _, in := range [][]byte{p7b, p7c} {
+		der, err := decodeCertificate(in)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if c.Subject.CommonName != "bundle.example.com" {
+			t.Errorf("Expected end-entity certificate bundle.example.com, got %s", c.Subject.CommonName)
+		}
+	}
+
+	if _, err = parsePKCS7(certs[0]); err == nil {
+		t.Error("Expected an error parsing a certificate as PKCS#7 bundle")
+	}
+}
+
+func TestScanCertificates(t *testing.T) {
+	p7b, err := ioutil.ReadFile("./testdata/bundle/complete.p7b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	crt, err := ioutil.ReadFile("./testdata/uppercasesan.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		in    []byte
+		count int
+	}{
+		"DER PKCS#7":  {p7b, 3},
+		"PEM mixed":   {append(append(crt, pem.EncodeToMemory(&pem.Block{Type: "PKCS7", Bytes: p7b})...), crt...), 5},
+		"PEM invalid": {[]byte("-----BEGIN PKCS7-----\nMAA=\n-----END PKCS7-----\n"), 0},
+	}
+
+	for name, test := range tests {
+		var count, invalid int
+		err := scanCertificates(bytes.NewReader(test.in), func(der, pemCert []byte, err error) {
+			if der == nil {
+				invalid++
+				return
+			}
+			count++
+		})
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+		}
+		if count != test.count {
+			t.Errorf("%s: expected %d certificates, got %d", name, test.count, count)
+		}
+		if test.count == 0 && invalid == 0 {
+			t.Errorf("%s: expected an invalid bundle", name)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+
+	certasn1 "github.com/weyhmueller/certlint/asn1"
+	"github.com/weyhmueller/certlint/certdata"
+	"github.com/weyhmueller/certlint/ctlogs"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// checkPrecert verifies if the TBSCertificate of the precertificate, without
+// the poison extension, equals the TBSCertificate of the final certificate
+// without the SCT list as described in RFC 6962 section 3.1. The fields and
+// extensions that differ are reported separately.
+func checkPrecert(precert, final []byte) *errors.Errors {
+	var e = errors.New(nil)
+
+	pre, found, err := precertTBS(precert, certdata.PoisonOID)
+	if err != nil {
+		e.Err("Failed to parse precertificate: %s", err.Error())
+		return e
+	}
+	if !found {
+		e.Err("Precertificate does not contain the poison extension")
+		return e
+	}
+
+	// The SCTs can also be delivered via TLS or OCSP
+	tbs, _, err := precertTBS(final, ctlogs.SCTListOID)
+	if err != nil {
+		e.Err("Failed to parse certificate: %s", err.Error())
+		return e
+	}
+
+	if !bytes.Equal(pre.SerialNumber.FullBytes, tbs.SerialNumber.FullBytes) {
+		e.Err("Certificate serial number differs from the precertificate")
+	}
+	if !bytes.Equal(pre.Issuer.FullBytes, tbs.Issuer.FullBytes) {
+		e.Err("Certificate issuer differs from the precertificate")
+	}
+
+	if pre.Version != tbs.Version {
+		e.Err("Certificate %s differs from the precertificate", "version")
+	}
+	for _, f := range []struct {
+		name       string
+		pre, final []byte
+	}{
+		{"signature algorithm", pre.SignatureAlgorithm.FullBytes, tbs.SignatureAlgorithm.FullBytes},
+		{"validity", pre.Validity.FullBytes, tbs.Validity.FullBytes},
+		{"subject", pre.Subject.FullBytes, tbs.Subject.FullBytes},
+		{"public key", pre.PublicKey.FullBytes, tbs.PublicKey.FullBytes},
+		{"issuer unique ID", pre.UniqueID.Bytes, tbs.UniqueID.Bytes},
+		{"subject unique ID", pre.SubjectUniqueID.Bytes, tbs.SubjectUniqueID.Bytes},
+	} {
+		if !bytes.Equal(f.pre, f.final) {
+			e.Err("Certificate %s differs from the precertificate", f.name)
+		}
+	}
+
+	for _, oid := range diffExtensions(pre.Extensions, tbs.Extensions) {
+		e.Err("Certificate extension %s differs from the precertificate", oid)
+	}
+
+	// Only the order or encoding of the extensions can differ at this point
+	if len(e.List()) == 0 {
+		preDER, _ := asn1.Marshal(*pre)
+		tbsDER, _ := asn1.Marshal(*tbs)
+		if !bytes.Equal(preDER, tbsDER) {
+			e.Err("Certificate does not match the precertificate TBSCertificate")
+		}
+	}
+
+	return e
+}
+
+// diffExtensions returns the OIDs of the extensions that are missing in one of
+// the lists or differ in criticality or value
+func diffExtensions(a, b []pkix.Extension) []string {
+	var diff []string
+	for _, ea := range a {
+		var found bool
+		for _, eb := range b {
+			if ea.Id.Equal(eb.Id) {
+				found = ea.Critical == eb.Critical && bytes.Equal(ea.Value, eb.Value)
+				break
+			}
+		}
+		if !found {
+			diff = append(diff, ea.Id.String())
+		}
+	}
+	for _, eb := range b {
+		var found bool
+		for _, ea := range a {
+			if ea.Id.Equal(eb.Id) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff = append(diff, eb.Id.String())
+		}
+	}
+	return diff
+}
+
+// precertTBS returns the TBSCertificate without the extension with the given
+// oid, found is false if the certificate doesn't contain the oid.
+func precertTBS(der []byte, oid asn1.ObjectIdentifier) (tbs *certasn1.TBSCertificate, found bool, err error) {
+	var c certasn1.Certificate
+	if _, err := asn1.Unmarshal(der, &c); err != nil {
+		return nil, false, err
+	}
+
+	var exts []pkix.Extension
+	for _, ext := range c.TBSCertificate.Extensions {
+		if ext.Id.Equal(oid) {
+			found = true
+			continue
+		}
+		exts = append(exts, ext)
+	}
+
+	c.TBSCertificate.Extensions = exts
+	return &c.TBSCertificate, found, nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// precertGolden contains the findings of a precertificate compared with a
+// certificate it wasn't issued for
+const precertGolden = "./testdata/golden/precert-nokeyusage.golden"
+
+func TestCheckPrecert(t *testing.T) {
+	precert := getCertificate("./testdata/precert.pem")
+	final := getCertificate("./testdata/precertfinal.pem")
+
+	if e := checkPrecert(precert, final); len(e.List()) != 0 {
+		t.Errorf("Unexpected findings for a matching precertificate: %v", e.List())
+	}
+
+	// The fields and extensions that differ are compared with a golden file
+	mismatch := checkPrecert(precert, getCertificate("./testdata/nokeyusage.pem"))
+	got := strings.Join(canonical(testResult{Errors: mismatch}), "\n") + "\n"
+	if *updateGolden {
+		if err := ioutil.WriteFile(precertGolden, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	} else if want, err := ioutil.ReadFile(precertGolden); err != nil || got != string(want) {
+		t.Errorf("Unexpected findings for a mismatched precertificate\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	if e := checkPrecert(final, final); len(e.List()) != 1 {
+		t.Errorf("Expected one finding for a precertificate without poison, got %d", len(e.List()))
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// lintProfile bundles the checks, certificate types and severities that
+// apply to certificates issued under a set of requirements.
+type lintProfile struct {
+	Description  string
+	Exclude      []string
+	Types        []string
+	Overrides    []severityOverride
+	RequirePSD2  bool
+	RequireSMIME bool
+}
+
+// baselineChecks are the checks of CA/Browser Forum requirements that have
+// no equivalent in RFC 5280.
+var baselineChecks = []string{
+	"Certificate Transparency Check",
+	"Certificate Transparency Extension Check",
+	"Public Suffix (xTLD) Check",
+	"Internal Names and IP addresses Check",
+	"Certificate Policies Check",
+	"Authority Info Access Issuers Check",
+	"Wildcard(s) Check",
+}
+
+// lintProfiles contains the profiles selectable with -profile
+var lintProfiles = map[string]lintProfile{
+	"cabf-br": {
+		Description: "CA/Browser Forum Baseline Requirements, all checks",
+	},
+	"cabf-ev": {
+		Description: "CA/Browser Forum EV Guidelines, all checks of EV certificates",
+		Types:       []string{"EV"},
+	},
+	"mozilla": {
+		Description: "Mozilla Root Store Policy, missing CT information is a notice",
+		Overrides: []severityOverride{
+			{Check: "Certificate Transparency Check", Severity: errors.Notice},
+		},
+	},
+	"rfc5280-only": {
+		Description: "RFC 5280 only, without the CA/Browser Forum requirements",
+		Exclude:     append([]string{"Public Key Check", "Signature Algorithm Check"}, baselineChecks...),
+		Overrides: []severityOverride{
+			{Check: "Validity Check", Match: regexp.MustCompile(`LifeTime exceeds|more than 5 years`), Severity: errors.Info},
+			{Check: "Certificate Serial Number Check", Match: regexp.MustCompile(`64 bits|unpredictable`), Severity: errors.Info},
+		},
+	},
+	"private-pki": {
+		Description: "Private PKI, without the requirements of publicly trusted certificates",
+		Exclude: []string{
+			"Certificate Transparency Check",
+			"Certificate Transparency Extension Check",
+			"Public Suffix (xTLD) Check",
+			"Internal Names and IP addresses Check",
+			"Authority Info Access Issuers Check",
+		},
+		Overrides: []severityOverride{
+			{Check: "Certificate Policies Check", Severity: errors.Notice},
+			{Check: "PolicyIdentifiers Extension Check", Severity: errors.Notice},
+			{Check: "Validity Check", Match: regexp.MustCompile(`LifeTime exceeds|more than 5 years`), Severity: errors.Notice},
+		},
+	},
+	"cabf-smime": {
+		Description:  "CA/Browser Forum S/MIME Baseline Requirements, all checks of S/MIME certificates",
+		Types:        []string{"PS"},
+		RequireSMIME: true,
+	},
+	"etsi-psd2": {
+		Description: "ETSI TS 119 495, PSD2 qualified website and seal certificates",
+		RequirePSD2: true,
+	},
+}
+
+// getProfile returns the lint profile with the given name
+func getProfile(name string) (lintProfile, error) {
+	p, ok := lintProfiles[name]
+	if !ok {
+		names := make([]string, 0, len(lintProfiles))
+		for n := range lintProfiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return p, fmt.Errorf("unknown profile '%s', available profiles: %s", name, strings.Join(names, ", "))
+	}
+	return p, nil
+}
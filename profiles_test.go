@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+func TestLintProfiles(t *testing.T) {
+	defer checks.Select(nil, nil)
+	for name, p := range lintProfiles {
+		if err := checks.Select(nil, p.Exclude); err != nil {
+			t.Errorf("Profile %s: %s", name, err)
+		}
+	}
+
+	if _, err := getProfile("cabf"); err == nil {
+		t.Error("Expected an error for an unknown profile")
+	}
+}
+
+func TestPrivatePKIProfile(t *testing.T) {
+	p, err := getProfile("private-pki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checks.Select(nil, p.Exclude); err != nil {
+		t.Fatal(err)
+	}
+	severityOverrides = p.Overrides
+	defer func() {
+		checks.Select(nil, nil)
+		severityOverrides = nil
+	}()
+
+	result := do(lru.New(200), getCertificate("./testdata/nokeyusage.pem"), nil, true, true)
+	for _, e := range result.Errors.List() {
+		switch e.Check() {
+		case "Certificate Transparency Check", "Internal Names and IP addresses Check":
+			t.Errorf("Unexpected finding of an excluded check: %s", e)
+		case "Certificate Policies Check":
+			if e.Priority() != errors.Notice {
+				t.Errorf("Expected notice for %s, got %s", e, e.Priority())
+			}
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/weyhmueller/certlint/checks"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// sarifGenericRule is used for findings that are not reported by a
+	// registered check, like encoding and chain errors
+	sarifGenericRule = "certlint"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string              `json:"id"`
+	Name                 string              `json:"name"`
+	ShortDescription     sarifMessage        `json:"shortDescription"`
+	FullDescription      *sarifMessage       `json:"fullDescription,omitempty"`
+	Help                 *sarifMessage       `json:"help,omitempty"`
+	DefaultConfiguration *sarifConfiguration `json:"defaultConfiguration,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Properties          map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifRuleID returns the SARIF rule id for a check name, which is the ID of
+// a registered check
+func sarifRuleID(check string) string {
+	if len(check) == 0 {
+		return sarifGenericRule
+	}
+	if m, ok := checks.Lookup(check); ok && len(m.ID) > 0 {
+		return m.ID
+	}
+	return check
+}
+
+// sarifLevel maps the priority of a finding to a SARIF level
+func sarifLevel(p errors.Priority) string {
+	switch {
+	case p >= errors.Error:
+		return "error"
+	case p == errors.Warning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// saveSARIF writes all results as a SARIF log, each finding refers to the
+// certificate file uri.
+func saveSARIF(filename, uri string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return writeSARIF(file, results, uri)
+}
+
+func writeSARIF(w io.Writer, results <-chan testResult, uri string) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "certlint",
+			InformationURI: "https://github.com/weyhmueller/certlint",
+		}},
+		Results: []sarifResult{},
+	}
+
+	// Highest level and check name per reported rule
+	levels := make(map[string]errors.Priority)
+	referenced := make(map[string]string)
+
+	// add reports a finding located in the file at location, the certificate
+	// is nil for the findings over all certificates
+	add := func(e errors.Err, location string, cert *x509.Certificate) {
+		id := sarifRuleID(e.Check())
+		referenced[id] = e.Check()
+		if e.Priority() > levels[id] {
+			levels[id] = e.Priority()
+		}
+
+		res := sarifResult{
+			RuleID:  id,
+			Level:   sarifLevel(e.Priority()),
+			Message: sarifMessage{Text: e.Error()},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: location},
+				},
+			}},
+		}
+		if cert != nil {
+			fingerprint := fmt.Sprintf("%x", sha256.Sum256(cert.Raw))
+			res.PartialFingerprints = map[string]string{"certificateSha256/v1": fingerprint}
+			res.Properties = map[string]string{
+				"fingerprint": fingerprint,
+				"subject":     cert.Subject.String(),
+				"serial":      formatSerial(cert.SerialNumber),
+			}
+		}
+		if code := e.Code(); len(code) > 0 {
+			if res.Properties == nil {
+				res.Properties = make(map[string]string)
+			}
+			res.Properties["code"] = code
+		}
+		run.Results = append(run.Results, res)
+	}
+
+	aggregates := newAggregates()
+	for r := range results {
+		for _, a := range aggregates {
+			a.Add(r)
+		}
+
+		// Point to the file of the certificate when known
+		location := uri
+		if len(r.Source) > 0 {
+			location = r.Source
+		}
+
+		for _, e := range r.Errors.List() {
+			add(e, location, r.Cert)
+		}
+	}
+
+	// Findings over all certificates in this run
+	for _, e := range checkAggregates(aggregates).List() {
+		add(e, uri, nil)
+	}
+
+	// Each registered check is a rule, as well as the checks that reported
+	// findings without being registered, like zlint
+	names := append(checks.Certificate.Names(), checks.Extensions.Names()...)
+	names = append(names, checks.CSR.Names()...)
+	names = append(names, "")
+	for _, name := range names {
+		if _, ok := referenced[sarifRuleID(name)]; !ok {
+			referenced[sarifRuleID(name)] = name
+		}
+	}
+	for id, name := range referenced {
+		rule := sarifRule{
+			ID:               id,
+			Name:             name,
+			ShortDescription: sarifMessage{Text: name},
+		}
+		switch name {
+		case "":
+			rule.Name = "Certificate Check"
+			rule.ShortDescription.Text = "Encoding, parsing and chain verification of the certificate, and the findings over all certificates"
+		case zlintCheck:
+			rule.ShortDescription.Text = "Findings reported by zlint"
+		}
+		if m, ok := checks.Lookup(name); ok {
+			if len(m.Description) > 0 {
+				rule.FullDescription = &sarifMessage{Text: m.Description}
+			}
+			if len(m.Citation) > 0 {
+				rule.Help = &sarifMessage{Text: m.Citation}
+			}
+		}
+		if p, ok := levels[rule.ID]; ok {
+			rule.DefaultConfiguration = &sarifConfiguration{Level: sarifLevel(p)}
+		}
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool {
+		return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID
+	})
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	issuer := "./testdata/golden/issuer.pem"
+	ch := make(chan testResult, 1)
+	ch <- do(nil, getCertificate("./testdata/httpsocsp.pem"), &issuer, true, true)
+	close(ch)
+
+	var buf bytes.Buffer
+	if err := writeSARIF(&buf, ch, "testdata/httpsocsp.pem"); err != nil {
+		t.Fatal(err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("Unexpected SARIF log version %s with %d runs", log.Version, len(log.Runs))
+	}
+
+	rules := make(map[string]sarifRule)
+	for _, r := range log.Runs[0].Tool.Driver.Rules {
+		rules[r.ID] = r
+	}
+	rule, ok := rules["certificate/revocation"]
+	if !ok {
+		t.Fatal("Expected a rule for the revocation check")
+	}
+	if rule.DefaultConfiguration == nil || rule.DefaultConfiguration.Level != "error" {
+		t.Errorf("Expected the revocation rule to have level error, got %+v", rule.DefaultConfiguration)
+	}
+
+	var found bool
+	for _, res := range log.Runs[0].Results {
+		if _, ok := rules[res.RuleID]; !ok {
+			t.Errorf("Result refers to unknown rule %s", res.RuleID)
+		}
+		if res.Locations[0].PhysicalLocation.ArtifactLocation.URI != "testdata/httpsocsp.pem" {
+			t.Errorf("Unexpected location %+v", res.Locations)
+		}
+		if len(res.PartialFingerprints["certificateSha256/v1"]) != 64 {
+			t.Errorf("Expected a certificate fingerprint, got %v", res.PartialFingerprints)
+		}
+		if res.RuleID == "certificate/revocation" && res.Level == "error" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected an error result for the https OCSP server")
+	}
+}
+
+func TestWriteSARIFSummary(t *testing.T) {
+	ch := make(chan testResult, 2)
+	for i := 0; i < 2; i++ {
+		r := newTestResult(t, 42, nil)
+		r.Errors = errors.New(nil)
+		r.Errors.Warning("zlint: %s", "w_example")
+		r.Errors.SetCheck(zlintCheck)
+		ch <- r
+	}
+	close(ch)
+
+	var buf bytes.Buffer
+	if err := writeSARIF(&buf, ch, "bulk.pem"); err != nil {
+		t.Fatal(err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+
+	rules := make(map[string]bool)
+	for _, r := range log.Runs[0].Tool.Driver.Rules {
+		rules[r.ID] = true
+	}
+
+	// The serial number collision is reported over both certificates
+	var found bool
+	for _, res := range log.Runs[0].Results {
+		if !rules[res.RuleID] {
+			t.Errorf("Result refers to unknown rule %s", res.RuleID)
+		}
+		if res.Properties["code"] == "CERTLINT_AGG_002" {
+			found = true
+			if res.RuleID != sarifGenericRule || res.Level != "error" || len(res.PartialFingerprints) != 0 {
+				t.Errorf("Unexpected summary result %+v", res)
+			}
+			if res.Locations[0].PhysicalLocation.ArtifactLocation.URI != "bulk.pem" {
+				t.Errorf("Unexpected location %+v", res.Locations)
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected a result for the serial number collision")
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// serialFormat is the encoding used to output serial numbers, one of hex,
+// hex-colon or decimal
+var serialFormat = "hex"
+
+// formatSerial returns the serial number in the configured serialFormat
+func formatSerial(n *big.Int) string {
+	if n == nil {
+		return ""
+	}
+
+	switch serialFormat {
+	case "decimal":
+		return n.String()
+	case "hex-colon":
+		b := n.Bytes()
+		if len(b) == 0 {
+			b = []byte{0}
+		}
+		s := make([]string, len(b))
+		for i := range b {
+			s[i] = fmt.Sprintf("%02x", b[i])
+		}
+		if n.Sign() < 0 {
+			return "-" + strings.Join(s, ":")
+		}
+		return strings.Join(s, ":")
+	default:
+		return fmt.Sprintf("%x", n)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFormatSerial(t *testing.T) {
+	defer func() { serialFormat = "hex" }()
+
+	n, _ := new(big.Int).SetString("2e57b76fcdb0624da9298ccc", 16)
+	tests := []struct {
+		format string
+		serial *big.Int
+		want   string
+	}{
+		{"hex", n, "2e57b76fcdb0624da9298ccc"},
+		{"hex-colon", n, "2e:57:b7:6f:cd:b0:62:4d:a9:29:8c:cc"},
+		{"decimal", n, "14342353253564757685740080332"},
+		{"hex", big.NewInt(0x1ab), "1ab"},
+		{"hex-colon", big.NewInt(0x1ab), "01:ab"},
+		{"hex-colon", big.NewInt(0), "00"},
+		{"decimal", big.NewInt(-42), "-42"},
+	}
+
+	for _, tc := range tests {
+		serialFormat = tc.format
+		if got := formatSerial(tc.serial); got != tc.want {
+			t.Errorf("Expected %s serial %s, got %s", tc.format, tc.want, got)
+		}
+	}
+}
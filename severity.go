@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// severityOverride changes the severity of the findings of a check or with a
+// code, only the findings matching Match when set.
+type severityOverride struct {
+	Check    string
+	Code     string
+	Match    *regexp.Regexp
+	Severity errors.Priority
+}
+
+// severityOverrides are applied to the findings of all checked certificates,
+// loaded with -severity-overrides.
+var severityOverrides []severityOverride
+
+// loadSeverityOverrides reads a JSON list of severity overrides, the first
+// matching override is used, e.g.
+//
+//	[{"check": "Certificate Revocation Information Check", "match": "non-preferred scheme", "severity": "notice"},
+//	 {"code": "CERTLINT_CT_001", "severity": "info"}]
+func loadSeverityOverrides(file string) ([]severityOverride, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var l []struct {
+		Check    string `json:"check"`
+		Code     string `json:"code"`
+		Match    string `json:"match"`
+		Severity string `json:"severity"`
+	}
+	if err := json.Unmarshal(b, &l); err != nil {
+		return nil, err
+	}
+
+	overrides := make([]severityOverride, 0, len(l))
+	for _, o := range l {
+		if len(o.Check) == 0 && len(o.Code) == 0 && len(o.Match) == 0 {
+			return nil, fmt.Errorf("severity override without check, code or match")
+		}
+
+		so := severityOverride{Check: o.Check, Code: o.Code}
+		if so.Severity, err = errors.ParsePriority(o.Severity); err != nil {
+			return nil, err
+		}
+		if len(o.Match) > 0 {
+			if so.Match, err = regexp.Compile(o.Match); err != nil {
+				return nil, err
+			}
+		}
+		overrides = append(overrides, so)
+	}
+	return overrides, nil
+}
+
+// overrideSeverity returns the findings with the severity of the first
+// matching override.
+func overrideSeverity(e *errors.Errors) *errors.Errors {
+	if len(severityOverrides) == 0 {
+		return e
+	}
+
+	return e.Map(func(err errors.Err) errors.Priority {
+		for _, o := range severityOverrides {
+			if len(o.Check) > 0 && o.Check != err.Check() {
+				continue
+			}
+			if len(o.Code) > 0 && o.Code != err.Code() {
+				continue
+			}
+			if o.Match != nil && !o.Match.MatchString(err.Error()) {
+				continue
+			}
+			return o.Severity
+		}
+		return err.Priority()
+	})
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+func TestSeverityOverrides(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "overrides.json")
+	err := ioutil.WriteFile(file, []byte(`[
+		{"check": "Certificate Transparency Check", "severity": "critical"},
+		{"match": "no key usage", "severity": "debug"}
+	]`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := loadSeverityOverrides(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	severityOverrides = l
+	defer func() { severityOverrides = nil }()
+
+	result := do(lru.New(200), getCertificate("./testdata/nokeyusage.pem"), nil, true, true)
+	var sct, keyUsage int
+	for _, e := range result.Errors.List() {
+		switch {
+		case e.Check() == "Certificate Transparency Check":
+			sct++
+			if e.Priority() != errors.Critical {
+				t.Errorf("Expected critical for %s, got %s", e, e.Priority())
+			}
+		case l[1].Match.MatchString(e.Error()):
+			keyUsage++
+			if e.Priority() != errors.Debug {
+				t.Errorf("Expected debug for %s, got %s", e, e.Priority())
+			}
+		}
+	}
+	if sct == 0 || keyUsage == 0 {
+		t.Errorf("Expected overridden findings, got %v", result.Errors.List())
+	}
+	if result.Errors.Priority() != errors.Critical {
+		t.Errorf("Expected critical as highest severity, got %s", result.Errors.Priority())
+	}
+}
+
+func TestLoadSeverityOverridesInvalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"empty.json":    `[{"severity": "notice"}]`,
+		"severity.json": `[{"check": "Key Usage Check", "severity": "fatal"}]`,
+		"match.json":    `[{"match": "[a-", "severity": "notice"}]`,
+		"json.json":     `{"check": "Key Usage Check"}`,
+	} {
+		file := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadSeverityOverrides(file); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+}
+
+func TestSeverityOverrideCode(t *testing.T) {
+	severityOverrides = []severityOverride{{Code: "CERTLINT_CT_001", Severity: errors.Debug}}
+	defer func() { severityOverrides = nil }()
+
+	result := do(lru.New(200), getCertificate("./testdata/nokeyusage.pem"), nil, true, true)
+	var found bool
+	for _, e := range result.Errors.List() {
+		if e.Code() == "CERTLINT_CT_001" {
+			found = true
+			if e.Priority() != errors.Debug {
+				t.Errorf("Expected debug for %s, got %s", e, e.Priority())
+			}
+		} else if e.Priority() == errors.Debug {
+			t.Errorf("Unexpected debug for %s", e)
+		}
+	}
+	if !found {
+		t.Errorf("Expected a finding with code CERTLINT_CT_001, got %v", result.Errors.List())
+	}
+}
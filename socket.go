@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/golang/groupcache/lru"
+)
+
+// socketRequest is a single line on the socket containing a DER encoded
+// certificate, encoded as base64 in JSON.
+type socketRequest struct {
+	ID   string `json:"id,omitempty"`
+	Cert []byte `json:"cert"`
+}
+
+// socketResponse contains the results of a socketRequest
+type socketResponse struct {
+	ID         string        `json:"id,omitempty"`
+	Type       string        `json:"type,omitempty"`
+	CAOperator string        `json:"ca_operator,omitempty"`
+	Trusted    bool          `json:"trusted"`
+	Findings   []jsonFinding `json:"findings"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// maxSocketRequest is the maximum length of a single request line
+const maxSocketRequest = 1024 * 1024
+
+// serveSocket accepts connections on the listener and checks all certificates
+// that are received until the listener is closed.
+func serveSocket(l net.Listener, exp bool) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go handleSocket(conn, exp)
+	}
+}
+
+// handleSocket reads newline delimited JSON requests and writes a JSON response
+// for each request.
+func handleSocket(conn net.Conn, exp bool) {
+	defer conn.Close()
+
+	// The lru cache is not safe for concurrent use, use one per connection
+	var icaCache = lru.New(200)
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 4096), maxSocketRequest)
+	enc := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		var req socketRequest
+		var resp socketResponse
+
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			resp.Error = fmt.Sprintf("Invalid request: %s", err.Error())
+		} else if len(req.Cert) == 0 {
+			resp.ID = req.ID
+			resp.Error = "Request contains no certificate"
+		} else {
+			result := do(icaCache, req.Cert, nil, exp, true)
+			resp.ID = req.ID
+			resp.Type = result.Type
+			resp.CAOperator = caOperator(result.Cert)
+			resp.Trusted = result.Trusted
+			resp.Findings = jsonFindings(result.Errors)
+		}
+
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
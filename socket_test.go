@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certlint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	l, err := net.Listen("unix", filepath.Join(dir, "certlint.sock"))
+	if err != nil {
+		t.Skipf("Unix sockets not available: %s", err.Error())
+	}
+	defer l.Close()
+	go serveSocket(l, true)
+
+	conn, err := net.Dial("unix", filepath.Join(dir, "certlint.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	req, _ := json.Marshal(socketRequest{ID: "1", Cert: getCertificate("./testdata/evissues.pem")})
+	conn.Write(append(req, '\n'))
+	conn.Write([]byte("invalid\n"))
+
+	reader := bufio.NewReader(conn)
+	var resp socketResponse
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(line, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.ID != "1" || resp.Type != "EV" || len(resp.Findings) == 0 {
+		t.Errorf("Unexpected response %+v", resp)
+	}
+
+	line, err = reader.ReadBytes('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp = socketResponse{}
+	if err := json.Unmarshal(line, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Error) == 0 {
+		t.Error("Expected an error for an invalid request")
+	}
+}
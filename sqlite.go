@@ -0,0 +1,144 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteSchema creates the tables of the SQLite report, a certificate can be
+// added multiple times when it is included more than once in a bulk file.
+var sqliteSchema = []string{
+	`CREATE TABLE IF NOT EXISTS certificates (
+		id INTEGER PRIMARY KEY,
+		source TEXT,
+		fingerprint TEXT,
+		subject TEXT,
+		issuer TEXT,
+		ca_operator TEXT,
+		serial TEXT,
+		type TEXT,
+		trusted INTEGER,
+		not_before TEXT,
+		not_after TEXT,
+		pem TEXT
+	)`,
+	`CREATE TABLE IF NOT EXISTS findings (
+		certificate_id INTEGER REFERENCES certificates(id), -- NULL for the findings over all certificates
+		check_name TEXT,
+		severity TEXT,
+		priority INTEGER,
+		message TEXT
+	)`,
+	`CREATE TABLE IF NOT EXISTS chains (
+		certificate_id INTEGER REFERENCES certificates(id),
+		position INTEGER,
+		fingerprint TEXT,
+		subject TEXT
+	)`,
+	`CREATE INDEX IF NOT EXISTS findings_certificate ON findings(certificate_id)`,
+	`CREATE INDEX IF NOT EXISTS findings_severity ON findings(severity)`,
+	`CREATE INDEX IF NOT EXISTS certificates_fingerprint ON certificates(fingerprint)`,
+}
+
+// saveSQLite writes all results in a SQLite database
+func saveSQLite(filename string) error {
+	db, err := sql.Open("sqlite3", filename)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return writeSQLite(db, results)
+}
+
+func writeSQLite(db *sql.DB, results <-chan testResult) error {
+	for _, s := range sqliteSchema {
+		if _, err := db.Exec(s); err != nil {
+			return err
+		}
+	}
+
+	// Insert all results in a single transaction, committing every insert
+	// separately is too slow for large bulk files
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	certStmt, err := tx.Prepare(`INSERT INTO certificates
+		(source, fingerprint, subject, issuer, ca_operator, serial, type, trusted, not_before, not_after, pem)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	findingStmt, err := tx.Prepare(`INSERT INTO findings
+		(certificate_id, check_name, severity, priority, message) VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	chainStmt, err := tx.Prepare(`INSERT INTO chains
+		(certificate_id, position, fingerprint, subject) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+
+	aggregates := newAggregates()
+	for r := range results {
+		for _, a := range aggregates {
+			a.Add(r)
+		}
+
+		var fingerprint, subject, issuer, operator, serial, notBefore, notAfter string
+		if r.Cert != nil {
+			fingerprint = sqliteFingerprint(r.Cert)
+			subject = r.Cert.Subject.String()
+			issuer = r.Cert.Issuer.String()
+			operator = caOperator(r.Cert)
+			serial = formatSerial(r.Cert.SerialNumber)
+			notBefore = r.Cert.NotBefore.UTC().Format("2006-01-02 15:04:05")
+			notAfter = r.Cert.NotAfter.UTC().Format("2006-01-02 15:04:05")
+		}
+
+		res, err := certStmt.Exec(r.Source, fingerprint, subject, issuer, operator, serial, r.Type, r.Trusted, notBefore, notAfter, r.Pem)
+		if err != nil {
+			return err
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		for _, e := range r.Errors.List() {
+			if _, err = findingStmt.Exec(id, e.Check(), strings.ToUpper(e.Priority().String()), int(e.Priority()), e.Error()); err != nil {
+				return err
+			}
+		}
+
+		if r.Cert != nil && r.Issuer != nil {
+			for i, c := range []*x509.Certificate{r.Cert, r.Issuer} {
+				if _, err = chainStmt.Exec(id, i, sqliteFingerprint(c), c.Subject.String()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Findings over all certificates in this run
+	for _, e := range checkAggregates(aggregates).List() {
+		if _, err = findingStmt.Exec(nil, e.Check(), strings.ToUpper(e.Priority().String()), int(e.Priority()), e.Error()); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func sqliteFingerprint(c *x509.Certificate) string {
+	return fmt.Sprintf("%x", sha256.Sum256(c.Raw))
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+func TestWriteSQLite(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "report.db"))
+	if err != nil {
+		t.Skip(err)
+	}
+	defer db.Close()
+	if err = db.Ping(); err != nil {
+		t.Skip(err)
+	}
+
+	issuer := "./testdata/golden/issuer.pem"
+	ch := make(chan testResult, 2)
+	ch <- do(nil, getCertificate("./testdata/httpsocsp.pem"), &issuer, true, true)
+	ch <- do(nil, getCertificate("./testdata/uppercasesan.pem"), &issuer, true, true)
+	close(ch)
+
+	if err = writeSQLite(db, ch); err != nil {
+		t.Fatal(err)
+	}
+
+	var n int
+	if err = db.QueryRow("SELECT COUNT(*) FROM certificates").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 certificates, got %d", n)
+	}
+
+	if err = db.QueryRow(`SELECT COUNT(*) FROM findings JOIN certificates ON certificates.id = findings.certificate_id
+		WHERE severity = 'ERROR' AND subject LIKE '%httpsocsp.example.com%'`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n == 0 {
+		t.Error("Expected error findings for httpsocsp.example.com")
+	}
+
+	if err = db.QueryRow("SELECT COUNT(*) FROM chains WHERE position = 1").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("Expected an issuer in the chain of 2 certificates, got %d", n)
+	}
+}
+
+func TestWriteSQLiteSummary(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "report.db"))
+	if err != nil {
+		t.Skip(err)
+	}
+	defer db.Close()
+	if err = db.Ping(); err != nil {
+		t.Skip(err)
+	}
+
+	ch := make(chan testResult, 2)
+	for i := 0; i < 2; i++ {
+		r := newTestResult(t, 42, nil)
+		r.Errors = errors.New(nil)
+		ch <- r
+	}
+	close(ch)
+
+	if err = writeSQLite(db, ch); err != nil {
+		t.Fatal(err)
+	}
+
+	var n int
+	if err = db.QueryRow(`SELECT COUNT(*) FROM findings WHERE certificate_id IS NULL
+		AND message LIKE '%share serial number%'`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("Expected a summary finding for the serial number collision, got %d", n)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// findingLogger is implemented by *syslog.Writer, it allows findings to be
+// written to any syslog compatible sink.
+type findingLogger interface {
+	Emerg(m string) error
+	Alert(m string) error
+	Crit(m string) error
+	Err(m string) error
+	Warning(m string) error
+	Notice(m string) error
+	Info(m string) error
+	Debug(m string) error
+}
+
+// findingLog receives all findings when syslog output is enabled
+var findingLog findingLogger
+
+// logFindings sends each finding of a test result to the logger with a syslog
+// level mapped from the priority of the finding.
+func logFindings(l findingLogger, r testResult) {
+	if r.Errors == nil {
+		return
+	}
+
+	var prefix string
+	if r.Cert != nil {
+		prefix = fmt.Sprintf("%s (%s): ", r.Cert.Subject.CommonName, formatSerial(r.Cert.SerialNumber))
+	}
+
+	for _, e := range r.Errors.List() {
+		msg := prefix + e.Error()
+		switch e.Priority() {
+		case errors.Emergency:
+			l.Emerg(msg)
+		case errors.Alert:
+			l.Alert(msg)
+		case errors.Critical:
+			l.Crit(msg)
+		case errors.Error:
+			l.Err(msg)
+		case errors.Warning:
+			l.Warning(msg)
+		case errors.Notice:
+			l.Notice(msg)
+		case errors.Info:
+			l.Info(msg)
+		default:
+			l.Debug(msg)
+		}
+	}
+}
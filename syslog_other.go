@@ -0,0 +1,11 @@
+//go:build windows || plan9 || nacl
+// +build windows plan9 nacl
+
+package main
+
+import "fmt"
+
+// newSyslog is not available on this platform
+func newSyslog(facility, tag string) (findingLogger, error) {
+	return nil, fmt.Errorf("Syslog is not supported on this platform")
+}
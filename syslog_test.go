@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+type fakeSyslog struct {
+	lines []string
+}
+
+func (f *fakeSyslog) log(level, m string) error {
+	f.lines = append(f.lines, fmt.Sprintf("%s %s", level, m))
+	return nil
+}
+
+func (f *fakeSyslog) Emerg(m string) error   { return f.log("emerg", m) }
+func (f *fakeSyslog) Alert(m string) error   { return f.log("alert", m) }
+func (f *fakeSyslog) Crit(m string) error    { return f.log("crit", m) }
+func (f *fakeSyslog) Err(m string) error     { return f.log("err", m) }
+func (f *fakeSyslog) Warning(m string) error { return f.log("warning", m) }
+func (f *fakeSyslog) Notice(m string) error  { return f.log("notice", m) }
+func (f *fakeSyslog) Info(m string) error    { return f.log("info", m) }
+func (f *fakeSyslog) Debug(m string) error   { return f.log("debug", m) }
+
+func TestLogFindings(t *testing.T) {
+	var r testResult
+	r.Errors = errors.New(nil)
+	r.Errors.Crit("Critical")
+	r.Errors.Err("Error")
+	r.Errors.Warning("Warning")
+	r.Errors.Info("Info")
+
+	f := new(fakeSyslog)
+	logFindings(f, r)
+
+	want := []string{"crit Critical", "err Error", "warning Warning", "info Info"}
+	if len(f.lines) != len(want) {
+		t.Fatalf("Unexpected number of syslog lines got %d, want %d", len(f.lines), len(want))
+	}
+	for i := range want {
+		if f.lines[i] != want[i] {
+			t.Errorf("Unexpected syslog line got '%s', want '%s'", f.lines[i], want[i])
+		}
+	}
+}
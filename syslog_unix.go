@@ -0,0 +1,41 @@
+//go:build !windows && !plan9 && !nacl
+// +build !windows,!plan9,!nacl
+
+package main
+
+import (
+	"fmt"
+	"log/syslog"
+)
+
+var syslogFacilities = map[string]syslog.Priority{
+	"kern":     syslog.LOG_KERN,
+	"user":     syslog.LOG_USER,
+	"mail":     syslog.LOG_MAIL,
+	"daemon":   syslog.LOG_DAEMON,
+	"auth":     syslog.LOG_AUTH,
+	"syslog":   syslog.LOG_SYSLOG,
+	"lpr":      syslog.LOG_LPR,
+	"news":     syslog.LOG_NEWS,
+	"uucp":     syslog.LOG_UUCP,
+	"cron":     syslog.LOG_CRON,
+	"authpriv": syslog.LOG_AUTHPRIV,
+	"ftp":      syslog.LOG_FTP,
+	"local0":   syslog.LOG_LOCAL0,
+	"local1":   syslog.LOG_LOCAL1,
+	"local2":   syslog.LOG_LOCAL2,
+	"local3":   syslog.LOG_LOCAL3,
+	"local4":   syslog.LOG_LOCAL4,
+	"local5":   syslog.LOG_LOCAL5,
+	"local6":   syslog.LOG_LOCAL6,
+	"local7":   syslog.LOG_LOCAL7,
+}
+
+// newSyslog connects to the local syslog daemon using the given facility name
+func newSyslog(facility, tag string) (findingLogger, error) {
+	f, ok := syslogFacilities[facility]
+	if !ok {
+		return nil, fmt.Errorf("Unknown syslog facility '%s'", facility)
+	}
+	return syslog.New(f|syslog.LOG_INFO, tag)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// trustStore is a named set of roots that trust is evaluated against, the
+// system roots are used when roots is nil.
+type trustStore struct {
+	name  string
+	roots *x509.CertPool
+}
+
+// namedTrustStores are the root stores that are loaded from <name>.pem in the
+// trust store directory, e.g. exported from the CCADB
+var namedTrustStores = []string{"mozilla", "microsoft", "apple"}
+
+// trustStores contains the stores selected with -trust-store
+var trustStores = []trustStore{{name: "system"}}
+
+// customRoots contains the roots of -roots, chains are only verified against
+// these roots when set
+var customRoots *x509.CertPool
+
+// useRoots restricts trust and chain verification to the roots in file
+func useRoots(file string) error {
+	roots, err := loadRoots(file)
+	if err != nil {
+		return fmt.Errorf("failed to load roots: %s", err)
+	}
+	customRoots = roots
+	trustStores = []trustStore{{name: "roots", roots: roots}}
+	return nil
+}
+
+// loadTrustStores loads the comma separated trust stores, a store is "system",
+// one of the named stores in dir or the path of a PEM or PKCS#7 file.
+func loadTrustStores(list, dir string) ([]trustStore, error) {
+	var stores []trustStore
+	for _, name := range splitList(list) {
+		if name == "system" {
+			stores = append(stores, trustStore{name: name})
+			continue
+		}
+
+		file := name
+		if isNamedTrustStore(name) {
+			file = filepath.Join(dir, name+".pem")
+		} else if _, err := os.Stat(file); err != nil {
+			return nil, fmt.Errorf("unknown trust store '%s', available stores: system, %s or a PEM file", name, strings.Join(namedTrustStores, ", "))
+		}
+
+		roots, err := loadRoots(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load trust store '%s': %s", name, err)
+		}
+		stores = append(stores, trustStore{name: name, roots: roots})
+	}
+
+	if len(stores) == 0 {
+		return nil, fmt.Errorf("no trust store selected")
+	}
+	return stores, nil
+}
+
+// isNamedTrustStore returns true if name is one of namedTrustStores
+func isNamedTrustStore(name string) bool {
+	for _, n := range namedTrustStores {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// loadRoots reads all certificates in a file as roots
+func loadRoots(file string) (*x509.CertPool, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pool := x509.NewCertPool()
+	var n int
+	err = scanCertificates(f, func(der, _ []byte, _ error) {
+		if der == nil {
+			return
+		}
+		if c, err := x509.ParseCertificate(der); err == nil {
+			pool.AddCert(c)
+			n++
+		}
+	})
+	if err == nil && n == 0 {
+		err = fmt.Errorf("no certificates found in %s", file)
+	}
+	return pool, err
+}
+
+// trustedBy returns the names of the selected trust stores the certificate
+// chains to, opts are the verify options without the roots.
+func trustedBy(c *x509.Certificate, opts x509.VerifyOptions) []string {
+	var names []string
+	for _, s := range trustStores {
+		opts.Roots = s.roots
+		if _, err := c.Verify(opts); err == nil {
+			names = append(names, s.name)
+		}
+	}
+	return names
+}
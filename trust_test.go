@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTrustStores(t *testing.T) {
+	b, err := ioutil.ReadFile("./testdata/bundle/complete.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first certificate is the test root, the last one the leaf
+	var ders [][]byte
+	for rest := b; ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		ders = append(ders, block.Bytes)
+	}
+
+	dir, err := ioutil.TempDir("", "certlint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	root := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ders[0]})
+	if err := ioutil.WriteFile(filepath.Join(dir, "mozilla.pem"), root, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadTrustStores("mozilla,microsoft", dir); err == nil {
+		t.Error("Expected an error for a missing trust store file")
+	}
+	if _, err := loadTrustStores("unknown", dir); err == nil {
+		t.Error("Expected an error for an unknown trust store")
+	}
+
+	stores, err := loadTrustStores("system,mozilla", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(s []trustStore) { trustStores = s }(trustStores)
+	trustStores = stores
+
+	chainBundle = newChainBundle(ders)
+	defer func() { chainBundle = nil }()
+
+	result := do(nil, ders[len(ders)-1], nil, true, true)
+	if !result.Trusted || !reflect.DeepEqual(result.TrustedBy, []string{"mozilla"}) {
+		t.Errorf("Expected the certificate to be trusted by mozilla only, got %t %v", result.Trusted, result.TrustedBy)
+	}
+}
+
+func TestCustomRoots(t *testing.T) {
+	f, err := os.Open("./testdata/bundle/complete.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// The first certificate is the test root, the last one the leaf
+	var ders [][]byte
+	scanCertificates(f, func(der, _ []byte, _ error) {
+		ders = append(ders, der)
+	})
+
+	// Without the test root the chain file does not verify
+	chainBundle = newChainBundle(ders[1:])
+	defer func() { chainBundle = nil }()
+	if result := do(nil, ders[len(ders)-1], nil, true, true); result.Trusted {
+		t.Error("Expected the certificate not to be trusted by the system roots")
+	}
+
+	file, err := ioutil.TempFile("", "roots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	pem.Encode(file, &pem.Block{Type: "CERTIFICATE", Bytes: ders[0]})
+	file.Close()
+
+	defer func(s []trustStore) { trustStores, customRoots = s, nil }(trustStores)
+	if err := useRoots(file.Name()); err != nil {
+		t.Fatal(err)
+	}
+
+	chainBundle = newChainBundle(ders[1:])
+	result := do(nil, ders[len(ders)-1], nil, true, true)
+	if !result.Trusted || !reflect.DeepEqual(result.TrustedBy, []string{"roots"}) {
+		t.Errorf("Expected the certificate to be trusted by the custom roots, got %t %v", result.Trusted, result.TrustedBy)
+	}
+	if result.Issuer == nil || result.Issuer.Subject.CommonName != "Certlint Test Bundle CA" {
+		t.Errorf("Expected issuer Certlint Test Bundle CA, got %v", result.Issuer)
+	}
+}
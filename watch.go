@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchSettle is the time a file must be unchanged before it is checked, to
+// prevent checking files that are still being written
+var watchSettle = 500 * time.Millisecond
+
+// doWatch queues the certificates in all files matching the globs that are
+// created or changed in dir, until stop is closed.
+func doWatch(dir string, globs []string, stop <-chan struct{}) {
+	defer close(jobs)
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer w.Close()
+	if err = w.Add(dir); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Last write per file that has not been checked yet
+	pending := make(map[string]time.Time)
+	ticker := time.NewTicker(watchSettle / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			fmt.Printf("Checked %d certificates\n", count)
+			return
+
+		case ev, ok := <-w.Events:
+			if !ok {
+				return
+			}
+			if ev.Op&(fsnotify.Create|fsnotify.Write) != 0 && matchGlobs(ev.Name, globs) {
+				pending[ev.Name] = time.Now()
+			}
+
+		case err, ok := <-w.Errors:
+			if ok {
+				fmt.Fprintln(os.Stderr, err)
+			}
+
+		case now := <-ticker.C:
+			for path, t := range pending {
+				if now.Sub(t) < watchSettle {
+					continue
+				}
+				delete(pending, path)
+				if err := queueFile(path); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+				}
+			}
+		}
+	}
+}
+
+// interrupted returns a channel that is closed when the process receives an
+// interrupt or terminate signal
+func interrupted() <-chan struct{} {
+	stop := make(chan struct{})
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		close(stop)
+	}()
+	return stop
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDoWatch(t *testing.T) {
+	defer func(j chan bulkJob, c int64, s time.Duration) { jobs, count, watchSettle = j, c, s }(jobs, count, watchSettle)
+	jobs = make(chan bulkJob, 100)
+	watchSettle = 50 * time.Millisecond
+
+	dir := t.TempDir()
+	stop := make(chan struct{})
+	go doWatch(dir, []string{"*.pem"}, stop)
+	time.Sleep(100 * time.Millisecond)
+
+	pem, err := os.ReadFile("testdata/bundle/complete.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(filepath.Join(dir, "ignored.txt"), pem, 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "issued.pem")
+	if err = os.WriteFile(path, pem, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		select {
+		case job := <-jobs:
+			if job.source != path {
+				t.Errorf("Expected source %s, got %s", path, job.source)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected 3 certificates from %s, got %d", path, i)
+		}
+	}
+
+	close(stop)
+	for job := range jobs {
+		t.Errorf("Unexpected job from %s", job.source)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+
+	"github.com/weyhmueller/certlint/errors"
+)
+
+// zlintCheck is the check name of the findings reported by zlint
+const zlintCheck = "zlint"
+
+// withZlint includes the findings of zlint in the results of all checked
+// certificates
+var withZlint bool
+
+// zlintFindings maps zlint lint names to the certlint findings that report the
+// same issue, used to compare the results of both tools.
+var zlintFindings = map[string]*regexp.Regexp{
+	"e_sub_cert_key_usage_cert_sign_bit_set":         regexp.MustCompile(`^Certificate has key usage CertSign set$`),
+	"e_sub_cert_key_usage_crl_sign_bit_set":          regexp.MustCompile(`^Certificate has key usage CRLSign set$`),
+	"e_ext_san_missing":                              regexp.MustCompile(`^Certificate doesn't contain any subjectAltName$`),
+	"e_subject_common_name_not_from_san":             regexp.MustCompile(`^Certificate CN is not listed in subjectAltName$`),
+	"e_subject_common_name_not_exactly_from_san":     regexp.MustCompile(`^Certificate CN (is not listed in|'.*' differs in case from the) subjectAltName$`),
+	"n_subject_common_name_included":                 regexp.MustCompile(`^commonName field is deprecated$`),
+	"e_dnsname_not_valid_tld":                        regexp.MustCompile(`internal server name`),
+	"e_sub_cert_or_sub_ca_using_sha1":                regexp.MustCompile(`^Certificate is using SHA1, but`),
+	"e_rsa_mod_less_than_2048_bits":                  regexp.MustCompile(`^Certificate key too small`),
+	"e_sub_cert_valid_time_longer_than_39_months":    regexp.MustCompile(`^Certificate LifeTime exceeds 39 months$`),
+	"e_sub_cert_valid_time_longer_than_825_days":     regexp.MustCompile(`^Certificate LifeTime exceeds 825 days$`),
+	"e_sub_cert_valid_time_longer_than_398_days":     regexp.MustCompile(`^Certificate LifeTime exceeds 398 days$`),
+	"e_sub_cert_aia_does_not_contain_ocsp_url":       regexp.MustCompile(`^Certificate contains no CRL or OCSP server$`),
+	"e_sub_cert_aia_missing":                         regexp.MustCompile(`^Certificate contains no Authority Info Access Issuers$`),
+	"e_sub_cert_locality_name_must_appear":           regexp.MustCompile(`^localityName or stateOrProvinceName is required`),
+	"e_sub_cert_province_must_appear":                regexp.MustCompile(`^(localityName or )?stateOrProvinceName is required`),
+	"e_subject_country_not_iso":                      regexp.MustCompile(`^countryName MUST contain the two-letter ISO 3166-1 country code$`),
+	"e_sub_cert_eku_server_auth_client_auth_missing": regexp.MustCompile(`^Certificate contains a key usage different from ServerAuth`),
+	"e_sub_cert_not_is_ca":                           regexp.MustCompile(`^Certificate has set CA true$`),
+	"e_serial_number_not_positive":                   regexp.MustCompile(`^Certificate serial number MUST be a positive integer`),
+	"e_serial_number_low_entropy":                    regexp.MustCompile(`^Certificate serial number should be 64 bits`),
+	"e_dnsname_wildcard_only_in_left_label":          regexp.MustCompile(`wildcard is only allowed as prefix$`),
+	"e_dnsname_left_label_wildcard_correct":          regexp.MustCompile(`wildcard is not the complete left most label$`),
+	"e_dnsname_wildcard_left_of_public_suffix":       regexp.MustCompile(`wildcard is directly under the public suffix`),
+	"e_ext_san_contains_reserved_ip":                 regexp.MustCompile(`contains a (non global unicast|private or local|reserved) IP address$`),
+	"e_ext_authority_key_identifier_critical":        regexp.MustCompile(`^AuthorityKeyId extension set critical$`),
+	"e_ext_subject_key_identifier_critical":          regexp.MustCompile(`^SubjectKeyId extension set critical$`),
+	"e_ext_aia_marked_critical":                      regexp.MustCompile(`^AuthorityInfoAccess extension set critical$`),
+	"e_ext_san_not_critical_without_subject":         regexp.MustCompile(`^SubjectAltName extension set critical$`),
+	"w_ext_key_usage_not_critical":                   regexp.MustCompile(`^KeyUsage extension SHOULD be marked as critical`),
+	"e_basic_constraints_not_critical":               regexp.MustCompile(`^BasicConstraints extension must be critical`),
+	"e_utc_time_not_in_zulu":                         regexp.MustCompile(`^UTCTime not in Zulu/GMT$`),
+	"e_generalized_time_not_in_zulu":                 regexp.MustCompile(`^Generalized Time not in Zulu/GMT$`),
+	"e_wrong_time_format_pre2050":                    regexp.MustCompile(`^Generalized Time before 2050$`),
+	"e_ev_organization_name_missing":                 regexp.MustCompile(`^organizationName is required for EV certificates$`),
+	"e_ev_locality_missing":                          regexp.MustCompile(`^localityName is required for EV certificates$`),
+	"e_ev_business_category_missing":                 regexp.MustCompile(`^businessCategory is required for EV certificates$`),
+	"e_ev_serial_number_missing":                     regexp.MustCompile(`^serialNumber is required for EV certificates$`),
+	"e_ev_valid_time_too_long":                       regexp.MustCompile(`^EV Certificate LifeTime exceeds 27 months$`),
+	"w_sub_cert_certificate_policies_missing":        regexp.MustCompile(`^Certificate doesn't contain any certificate policies$`),
+	"e_cert_contains_unique_identifier":              regexp.MustCompile(`^Certificate is not V3`),
+}
+
+// zlintDiff contains the differences between the certlint and zlint findings
+type zlintDiff struct {
+	Both         []string // zlint lints also reported by certlint
+	CertlintOnly []string // certlint findings not reported by zlint
+	ZlintOnly    []string // zlint lints not reported by certlint
+}
+
+// runZlint runs the zlint binary from the PATH on the DER encoded certificate
+// and returns the lints that did not pass.
+func runZlint(der []byte) (map[string]string, error) {
+	path, err := exec.LookPath("zlint")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(path, "-format", "der")
+	cmd.Stdin = bytes.NewReader(der)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("zlint failed: %s", err.Error())
+	}
+
+	var lints map[string]struct {
+		Result string `json:"result"`
+	}
+	if err := json.Unmarshal(out, &lints); err != nil {
+		return nil, fmt.Errorf("failed to parse zlint output: %s", err.Error())
+	}
+
+	results := make(map[string]string)
+	for name, l := range lints {
+		switch l.Result {
+		case "info", "notice", "warn", "error", "fatal":
+			results[name] = l.Result
+		}
+	}
+	return results, nil
+}
+
+// zlintErrors runs zlint on the DER encoded certificate and returns the lints
+// that did not pass as findings of the zlint check.
+func zlintErrors(der []byte) *errors.Errors {
+	var e = errors.New(nil)
+	defer e.SetCheck(zlintCheck)
+
+	lints, err := runZlint(der)
+	if err != nil {
+		e.Err("Failed to run zlint: %s", err.Error())
+		return e
+	}
+
+	// Sort the lints to get the same order for every run
+	names := make([]string, 0, len(lints))
+	for name := range lints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		switch lints[name] {
+		case "info":
+			e.Info("zlint: %s", name)
+		case "notice":
+			e.Notice("zlint: %s", name)
+		case "warn":
+			e.Warning("zlint: %s", name)
+		case "error":
+			e.Err("zlint: %s", name)
+		case "fatal":
+			e.Crit("zlint: %s", name)
+		}
+	}
+	return e
+}
+
+// diffZlint compares the certlint findings with the zlint results
+func diffZlint(findings []string, zlint map[string]string) zlintDiff {
+	var diff zlintDiff
+	matched := make(map[string]bool)
+
+	for name := range zlint {
+		re, ok := zlintFindings[name]
+		found := false
+		if ok {
+			for _, f := range findings {
+				if re.MatchString(f) {
+					matched[f] = true
+					found = true
+				}
+			}
+		}
+		if found {
+			diff.Both = append(diff.Both, name)
+		} else {
+			diff.ZlintOnly = append(diff.ZlintOnly, fmt.Sprintf("%s (%s)", name, zlint[name]))
+		}
+	}
+
+	for _, f := range findings {
+		if !matched[f] {
+			diff.CertlintOnly = append(diff.CertlintOnly, f)
+		}
+	}
+
+	sort.Strings(diff.Both)
+	sort.Strings(diff.ZlintOnly)
+	return diff
+}
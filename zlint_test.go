@@ -0,0 +1,99 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/weyhmueller/certlint/errors"
+)
+
+func TestDiffZlint(t *testing.T) {
+	findings := []string{
+		"Certificate has key usage CertSign set",
+		"Certificate doesn't contain any subjectAltName",
+		"Certificate contains no embedded SCT list",
+		"Certificate subjectAltName '192.0.2.1' contains a reserved IP address",
+	}
+	zlint := map[string]string{
+		"e_ext_san_contains_reserved_ip":                 "error",
+		"e_sub_cert_key_usage_cert_sign_bit_set":         "error",
+		"e_ext_san_missing":                              "error",
+		"w_sub_cert_aia_does_not_contain_issuing_ca_url": "warn",
+	}
+
+	diff := diffZlint(findings, zlint)
+	if !reflect.DeepEqual(diff.Both, []string{"e_ext_san_contains_reserved_ip", "e_ext_san_missing", "e_sub_cert_key_usage_cert_sign_bit_set"}) {
+		t.Errorf("Unexpected findings reported by both: %v", diff.Both)
+	}
+	if !reflect.DeepEqual(diff.CertlintOnly, []string{"Certificate contains no embedded SCT list"}) {
+		t.Errorf("Unexpected findings only reported by certlint: %v", diff.CertlintOnly)
+	}
+	if !reflect.DeepEqual(diff.ZlintOnly, []string{"w_sub_cert_aia_does_not_contain_issuing_ca_url (warn)"}) {
+		t.Errorf("Unexpected findings only reported by zlint: %v", diff.ZlintOnly)
+	}
+}
+
+func TestCompareZlint(t *testing.T) {
+	if _, err := exec.LookPath("zlint"); err != nil {
+		t.Skip("zlint not found on the PATH")
+	}
+
+	der := getCertificate("./testdata/nokeyusage.pem")
+	result := do(lru.New(200), der, nil, true, true)
+
+	zlint, err := runZlint(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zlint) == 0 {
+		t.Error("Expected zlint to report some findings")
+	}
+
+	var findings []string
+	for _, err := range result.Errors.List() {
+		findings = append(findings, err.Error())
+	}
+	diff := diffZlint(findings, zlint)
+	if len(diff.Both)+len(diff.ZlintOnly) != len(zlint) {
+		t.Errorf("Expected all %d zlint findings in the diff, got %v", len(zlint), diff)
+	}
+}
+
+func TestZlintErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake zlint is a shell script")
+	}
+
+	// Replace zlint on the PATH with a script reporting fixed results
+	dir := t.TempDir()
+	script := `#!/bin/sh
+cat >/dev/null
+echo '{"n_subject_common_name_included":{"result":"notice"},"e_ext_san_missing":{"result":"error"},"e_sub_cert_not_is_ca":{"result":"pass"}}'
+`
+	if err := os.WriteFile(filepath.Join(dir, "zlint"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+
+	e := zlintErrors(getCertificate("./testdata/nokeyusage.pem"))
+	list := e.List()
+	if len(list) != 2 {
+		t.Fatalf("Expected 2 zlint findings, got %v", list)
+	}
+	if list[0].Error() != "zlint: e_ext_san_missing" || list[0].Priority() != errors.Error {
+		t.Errorf("Unexpected first finding %s (%s)", list[0], list[0].Priority())
+	}
+	if list[1].Error() != "zlint: n_subject_common_name_included" || list[1].Priority() != errors.Notice {
+		t.Errorf("Unexpected second finding %s (%s)", list[1], list[1].Priority())
+	}
+	for _, err := range list {
+		if linter := reportColumns["linter"].value(nil, err); linter != "zlint" {
+			t.Errorf("Expected linter zlint for %s, got %s", err, linter)
+		}
+	}
+}